@@ -0,0 +1,140 @@
+package rrh
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+AssignmentRule represents the rule for assigning the group to the repository when the repository is registered.
+The string form of the rule is `RULE[&RULE...]=GROUP`, and RULE is the form of GroupRule (`FIELD:PATTERN`).
+The repository satisfying all of the rules is related to the group.
+For example, `owner:acme=work` assigns the repositories of acme to the group work,
+and `path:~/src/oss&file:go.mod=oss-go` assigns the go repositories in ~/src/oss to the group oss-go.
+*/
+type AssignmentRule struct {
+	Rules []*GroupRule
+	Group string
+}
+
+func (ar *AssignmentRule) String() string {
+	var rules = []string{}
+	for _, rule := range ar.Rules {
+		rules = append(rules, rule.String())
+	}
+	return strings.Join(rules, "&") + "=" + ar.Group
+}
+
+/*
+ParseAssignmentRules parses the value of RRH_ASSIGNMENT_RULES, which is the comma separated list of the assignment rules.
+*/
+func ParseAssignmentRules(value string) ([]*AssignmentRule, error) {
+	var results = []*AssignmentRule{}
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		var rule, err = ParseAssignmentRule(item)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rule)
+	}
+	return results, nil
+}
+
+/*
+ParseAssignmentRule parses the given string in the form of `RULE[&RULE...]=GROUP` to AssignmentRule.
+Since the tag rule may contain `=`, the last `=` separates the rules and the group.
+*/
+func ParseAssignmentRule(str string) (*AssignmentRule, error) {
+	var index = strings.LastIndex(str, "=")
+	if index < 0 || strings.TrimSpace(str[index+1:]) == "" {
+		return nil, fmt.Errorf("%s: invalid assignment rule, the rule must be RULE=GROUP form", str)
+	}
+	var result = &AssignmentRule{Rules: []*GroupRule{}, Group: strings.TrimSpace(str[index+1:])}
+	for _, item := range strings.Split(str[:index], "&") {
+		var rule, err = ParseGroupRule(item)
+		if err != nil {
+			return nil, err
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	return result, nil
+}
+
+/*
+Match returns true if the given repository satisfies all of the rules.
+*/
+func (ar *AssignmentRule) Match(repo *Repository) bool {
+	for _, rule := range ar.Rules {
+		if !rule.Match(repo) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+AssignmentRules returns the assignment rules defined in RRH_ASSIGNMENT_RULES.
+The invalid definitions are ignored.
+*/
+func (config *Config) AssignmentRules() []*AssignmentRule {
+	var rules, err = ParseAssignmentRules(config.GetValue(AssignmentRules))
+	if err != nil {
+		return []*AssignmentRule{}
+	}
+	return rules
+}
+
+/*
+FindAssignedGroups returns the names of the groups which the given repository should belong to by the assignment rules.
+The dynamic groups are not assigned, since their members are decided by their own rules.
+*/
+func (db *Database) FindAssignedGroups(repo *Repository) []string {
+	var results = []string{}
+	for _, rule := range db.Config.AssignmentRules() {
+		if !rule.Match(repo) || FindIn(rule.Group, results) || db.isDynamicGroup(rule.Group) {
+			continue
+		}
+		results = append(results, rule.Group)
+	}
+	return results
+}
+
+/*
+AssignGroups relates the repository of the given id to the groups decided by the assignment rules.
+The groups not existing are created, since the rules explicitly request them (see RelateToGroups).
+This function returns the names of the newly related groups.
+*/
+func (db *Database) AssignGroups(repoID string) ([]string, error) {
+	var repo = db.FindRepository(repoID)
+	if repo == nil {
+		return nil, fmt.Errorf("%s: repository not found", repoID)
+	}
+	return db.RelateToGroups(repoID, db.FindAssignedGroups(repo))
+}
+
+/*
+RelateToGroups relates the repository of the given id to the given groups, and creates the groups not existing.
+The dynamic groups are skipped, since they are read-only.
+This function returns the names of the newly related groups.
+*/
+func (db *Database) RelateToGroups(repoID string, groups []string) ([]string, error) {
+	var results = []string{}
+	for _, group := range groups {
+		if db.HasRelation(group, repoID) || db.isDynamicGroup(group) {
+			continue
+		}
+		if !db.HasGroup(group) {
+			if _, err := db.CreateGroup(group, "", false); err != nil {
+				return results, err
+			}
+		}
+		if err := db.Relate(group, repoID); err != nil {
+			return results, err
+		}
+		results = append(results, group)
+	}
+	return results, nil
+}
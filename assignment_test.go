@@ -0,0 +1,97 @@
+package rrh
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseAssignmentRules(t *testing.T) {
+	var testcases = []struct {
+		giveString string
+		errorFlag  bool
+		wontString string
+	}{
+		{"owner:acme=work", false, "owner:acme=work"},
+		{"owner:acme=work, path:~/src/oss=oss", false, "owner:acme=work,path:~/src/oss=oss"},
+		{"tag:lang=go=golang", false, "tag:lang=go=golang"},
+		{"owner:acme&file:go.mod=acme-go", false, "owner:acme&file:go.mod=acme-go"},
+		{"", false, ""},
+		{"owner:acme", true, ""},
+		{"owner:acme=", true, ""},
+		{"unknown:acme=work", true, ""},
+	}
+	for _, tc := range testcases {
+		var rules, err = ParseAssignmentRules(tc.giveString)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("ParseAssignmentRules(%s) wont error %v, but got %v", tc.giveString, tc.errorFlag, err)
+		}
+		if err != nil {
+			continue
+		}
+		var strs = []string{}
+		for _, rule := range rules {
+			strs = append(strs, rule.String())
+		}
+		if strings.Join(strs, ",") != tc.wontString {
+			t.Errorf("ParseAssignmentRules(%s) did not match, wont: %s, got: %s", tc.giveString, tc.wontString, strings.Join(strs, ","))
+		}
+	}
+}
+
+func TestAssignGroups(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		if err := config.Update(AssignmentRules, "owner:acme"); err == nil {
+			t.Errorf("invalid assignment rules should not be set")
+		}
+		config.Update(AssignmentRules, "owner:example=example,id:repo*=group1,id:repo2=dynamic")
+		db.CreateGroup("dynamic", "", false)
+		db.SetGroupRules("dynamic", []string{"id:repo1"})
+
+		var testcases = []struct {
+			repoID       string
+			wontAssigned []string
+			wontNew      []string
+		}{
+			{"repo1", []string{"group1"}, []string{}},
+			{"repo2", []string{"example", "group1"}, []string{"example", "group1"}},
+		}
+		for _, tc := range testcases {
+			var assigned = db.FindAssignedGroups(db.FindRepository(tc.repoID))
+			if strings.Join(assigned, ",") != strings.Join(tc.wontAssigned, ",") {
+				t.Errorf("%s: assigned groups did not match, wont: %v, got: %v", tc.repoID, tc.wontAssigned, assigned)
+			}
+			var groups, err = db.AssignGroups(tc.repoID)
+			if err != nil {
+				t.Errorf("%s: assign groups failed: %s", tc.repoID, err.Error())
+			}
+			if strings.Join(groups, ",") != strings.Join(tc.wontNew, ",") {
+				t.Errorf("%s: newly assigned groups did not match, wont: %v, got: %v", tc.repoID, tc.wontNew, groups)
+			}
+		}
+		if !db.HasGroup("example") || !db.HasRelation("example", "repo2") {
+			t.Errorf("assigned group should be created")
+		}
+		if _, err := db.AssignGroups("unknown"); err == nil {
+			t.Errorf("assigning groups to unknown repository should be error")
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestRelateToGroupsWithoutAutoCreate(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		config.Update(AutoCreateGroup, "false")
+		var groups, err = db.RelateToGroups("repo1", []string{"not-exist1", "group1", "group2"})
+		if err != nil {
+			t.Errorf("relating to groups should not be error, got: %s", err.Error())
+		}
+		if strings.Join(groups, ",") != "not-exist1,group2" {
+			t.Errorf("only newly related groups should be returned, got: %v", groups)
+		}
+		if !db.HasGroup("not-exist1") || !db.HasRelation("not-exist1", "repo1") || !db.HasRelation("group2", "repo1") {
+			t.Errorf("groups should be created and related regardless of %s", AutoCreateGroup)
+		}
+	})
+	defer os.Remove(dbFile)
+}
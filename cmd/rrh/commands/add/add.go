@@ -3,8 +3,10 @@ package add
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
@@ -12,12 +14,14 @@ import (
 )
 
 type addOptions struct {
-	groups     []string
-	repoId     string
-	dryRunFlag bool
+	groups        []string
+	repoId        string
+	dryRunFlag    bool
+	recursiveFlag bool
+	walk          *rrh.WalkOption
 }
 
-var addOpts = &addOptions{groups: []string{}}
+var addOpts = &addOptions{groups: []string{}, walk: rrh.NewWalkOption()}
 
 func New() *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,6 +36,9 @@ func New() *cobra.Command {
 	flags.StringSliceVarP(&addOpts.groups, "group", "g", []string{"no-group"}, "group for the repositories")
 	flags.StringVarP(&addOpts.repoId, "repository-id", "r", "", "specifies the repository id. Specifying this option fails on multiple arguments")
 	flags.BoolVarP(&addOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	flags.BoolVarP(&addOpts.recursiveFlag, "recursive", "R", false, "add all of the git repositories under the given directories")
+	utils.AddWalkFlags(cmd, addOpts.walk)
+	cmd.RegisterFlagCompletionFunc("group", utils.CompleteGroups)
 	return cmd
 }
 
@@ -43,9 +50,39 @@ func validateArgs(c *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return errors.New("too few arguments")
 	}
+	recursive, _ := c.Flags().GetBool("recursive")
+	if recursive {
+		if repo != "" {
+			return errors.New("repository-id is not available in the recursive mode")
+		}
+		return validateDirs(args)
+	}
 	return validateGitDirs(args)
 }
 
+func validateDirs(args []string) error {
+	messages := common.NewErrorList()
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err != nil {
+			messages = messages.Append(fmt.Errorf("%s: directory not found", arg))
+		} else if !info.IsDir() {
+			messages = messages.Append(fmt.Errorf("%s: not a directory", arg))
+		}
+	}
+	return messages.NilOrThis()
+}
+
+/*
+findTargets returns the paths of the repositories to add.
+In the recursive mode, the git repositories under the given directories are the targets.
+*/
+func findTargets(args []string) ([]string, error) {
+	if !addOpts.recursiveFlag {
+		return args, nil
+	}
+	return addOpts.walk.FindGitRepositories(args...)
+}
+
 func validateGitDirs(args []string) error {
 	messages := common.NewErrorList()
 	for _, arg := range args {
@@ -60,20 +97,34 @@ func validateGitDirs(args []string) error {
 }
 
 func perform(c *cobra.Command, args []string, db *rrh.Database) error {
-	el := createGroups(db, addOpts.groups)
+	var useDefault = !c.Flags().Changed("group")
+	el := common.NewErrorList()
+	if !useDefault {
+		el = createGroups(db, addOpts.groups)
+	}
 	if el.IsErr() {
 		return el
 	}
-	for _, targetPath := range args {
-		err := addRepositoryToGroup(db, targetPath, addOpts.groups)
-		el.Append(err)
+	targets, err := findTargets(args)
+	if err != nil {
+		return err
 	}
-	if el.IsErr() {
-		return el
+	if addOpts.recursiveFlag {
+		c.Printf("%s found\n", english.Plural(len(targets), "repository", "repositories"))
+	}
+	var failed = 0
+	for _, targetPath := range targets {
+		if err := addRepositoryToGroup(db, targetPath, addOpts.groups, useDefault); err != nil {
+			c.PrintErrf("%s: %s\n", targetPath, err.Error())
+			failed++
+		}
 	}
 	if !addOpts.dryRunFlag {
 		db.StoreAndClose()
 	}
+	if failed > 0 {
+		return fmt.Errorf("failed to add %s", english.Plural(failed, "repository", "repositories"))
+	}
 	return nil
 }
 
@@ -81,7 +132,7 @@ func createGroups(db *rrh.Database, groups []string) common.ErrorList {
 	el := common.NewErrorList()
 	for _, groupName := range groups {
 		_, err := db.AutoCreateGroup(groupName, "", false)
-		el.Append(err)
+		el = el.Append(err)
 	}
 	return el
 }
@@ -93,31 +144,107 @@ func findIDFromPath(repoIdFromOpts string, absPath string) string {
 	return repoIdFromOpts
 }
 
-func isDuplicateRepositoryId(db *rrh.Database, repoId, path string) error {
+/*
+registerRepository registers the repository of the given path.
+If the repository of the same id is registered with the other path,
+the path is added as the path of the current host when their remotes point the same repository,
+and the repository is not registered on the current host yet.
+*/
+func registerRepository(db *rrh.Database, repoId, path string, remotes []*rrh.Remote) error {
 	var repo = db.FindRepository(repoId)
-	if repo != nil && repo.Path != path {
+	if repo == nil {
+		var _, err = db.CreateRepository(repoId, path, "", remotes)
+		return err
+	}
+	if repo.Path == path {
+		return nil
+	}
+	var host = rrh.CurrentHostname(db.Config)
+	if !repo.HasSameRemote(remotes) || isRegisteredOn(repo, host) {
 		return fmt.Errorf("%s: duplicate repository id", repoId)
 	}
-	return nil
+	return db.PutHostPath(repoId, host, path)
+}
+
+/*
+isRegisteredOn returns true if the repository has the path on the given host.
+The path without the entry of the host is treated as the path on the host when it exists on this machine.
+*/
+func isRegisteredOn(repo *rrh.Repository, host string) bool {
+	if repo.FindHostPath(host) != nil {
+		return true
+	}
+	if !repo.IsResolved() {
+		return false
+	}
+	var _, err = os.Stat(repo.Path)
+	return err == nil
 }
 
-func addRepositoryToGroup(db *rrh.Database, path string, groupNames []string) error {
+/*
+addRepositoryToGroup registers the repository of the given path, and relates it to the groups (see Register).
+*/
+func addRepositoryToGroup(db *rrh.Database, path string, groupNames []string, useDefault bool) error {
 	var absPath, _ = filepath.Abs(path)
 	var id = findIDFromPath(addOpts.repoId, absPath)
-	if err1 := isDuplicateRepositoryId(db, id, absPath); err1 != nil {
-		return err1
+	var _, err = Register(db, id, absPath, groupNames, useDefault)
+	return err
+}
+
+/*
+Register registers the repository of the given path by the given id, and relates it to the groups.
+The repository registered on the other host is registered as the path of this host (see registerRepository).
+The groups decided by the assignment rules (RRH_ASSIGNMENT_RULES) are also related, and created if they do not exist.
+If useDefault is true (no groups are specified), the given groups are used only when no assignment rules match.
+The given groups not existing are created if RRH_AUTO_CREATE_GROUP is set,
+and are checked before registering, not to leave the repository without groups.
+This function returns the names of the groups which the repository is related to.
+*/
+func Register(db *rrh.Database, id, path string, groupNames []string, useDefault bool) ([]string, error) {
+	remotes, err1 := rrh.FindRemotes(path)
+	if err1 != nil {
+		return nil, err1
 	}
-	remotes, err2 := rrh.FindRemotes(absPath)
-	if err2 != nil {
-		return err2
+	if useDefault && isAssigned(db, id, path, remotes) {
+		groupNames = []string{}
+	} else if el := createGroups(db, groupNames); el.IsErr() {
+		return nil, el
+	}
+	if err2 := registerRepository(db, id, path, remotes); err2 != nil {
+		return nil, err2
+	}
+	if _, err3 := db.AssignGroups(id); err3 != nil {
+		return nil, err3
 	}
-	db.CreateRepository(id, absPath, "", remotes)
-
 	for _, groupName := range groupNames {
 		err := db.Relate(groupName, id)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return relatedGroups(db, id, append(db.FindAssignedGroups(db.FindRepository(id)), groupNames...)), nil
+}
+
+/*
+isAssigned returns true if any assignment rules match the repository to be registered by the given parameters.
+*/
+func isAssigned(db *rrh.Database, id, path string, remotes []*rrh.Remote) bool {
+	var probe = &rrh.Repository{ID: id, Path: path, Remotes: remotes}
+	if repo := db.FindRepository(id); repo != nil {
+		probe.Tags = repo.Tags
+	}
+	return len(db.FindAssignedGroups(probe)) > 0
+}
+
+/*
+relatedGroups returns the names of the given groups which the repository of the given id is related to.
+*/
+func relatedGroups(db *rrh.Database, id string, groups []string) []string {
+	var results = []string{}
+	for _, group := range groups {
+		if db.HasRelation(group, id) && !rrh.FindIn(group, results) {
+			results = append(results, group)
+		}
+	}
+	return results
 }
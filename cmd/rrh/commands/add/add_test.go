@@ -1,10 +1,16 @@
 package add
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
 )
 
 func TestAdd(t *testing.T) {
@@ -38,7 +44,7 @@ func TestAdd(t *testing.T) {
 			[]repositoryChecker{{"fibonacci", true}},
 			[]relationChecker{{"no-group", "fibonacci", true}},
 		},
-		{[]string{"../../../../testdata/fibonacci", "../../../../testdata/helloworld", "../../../../not-exist-dir", "../../../../testdata/other/helloworld"}, false,
+		{[]string{"../../../../testdata/fibonacci", "../../../../testdata/helloworld", "../../../../not-exist-dir", "../../../../testdata/other/helloworld"}, true,
 			[]groupChecker{{"no-group", true}},
 			[]repositoryChecker{{"fibonacci", true}, {"helloworld", true}, {"not-exist-dir", false}},
 			[]relationChecker{{"no-group", "fibonacci", true}, {"no-group", "helloworld", true}},
@@ -96,3 +102,170 @@ func TestAdd(t *testing.T) {
 		defer os.Remove(databaseFile)
 	}
 }
+
+func TestRegisterRepositoryOnAnotherHost(t *testing.T) {
+	var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		config.Update(rrh.Hostname, "workstation")
+		var remotes = []*rrh.Remote{{Name: "origin", URL: "https://github.com/example/repo2"}}
+		if err := registerRepository(db, "repo2", "/home/user/work/repo2", remotes); err != nil {
+			t.Errorf("repository with the same remote should be registered, but got error: %s", err.Error())
+		}
+		var repo = db.FindRepository("repo2")
+		if hp := repo.FindHostPath("workstation"); hp == nil || repo.Path != "/home/user/work/repo2" {
+			t.Errorf("path of the current host should be added, got: %s", repo.Path)
+		}
+		if err := registerRepository(db, "repo1", "/home/user/work/repo1", remotes); err == nil {
+			t.Errorf("repository with the different remotes should be error")
+		}
+		if err := registerRepository(db, "repo2", "/home/user/work/second-clone", remotes); err == nil {
+			t.Errorf("second clone on the same host should be error")
+		}
+		if hp := repo.FindHostPath("workstation"); hp.Path != "/home/user/work/repo2" {
+			t.Errorf("path of the current host should not be overwritten, got: %s", hp.Path)
+		}
+	})
+	defer os.Remove(databaseFile)
+}
+
+func TestRegisterSecondCloneOnSameHost(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-add")
+	defer os.RemoveAll(dir)
+	var first, second = filepath.Join(dir, "first"), filepath.Join(dir, "second")
+	git.PlainInit(first, false)
+	git.PlainInit(second, false)
+	var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		var remotes = []*rrh.Remote{{Name: "origin", URL: "https://github.com/example/clone"}}
+		registerRepository(db, "clone", first, remotes)
+		if err := registerRepository(db, "clone", second, remotes); err == nil {
+			t.Errorf("second clone on the same host should be error")
+		}
+		var repo = db.FindRepository("clone")
+		if repo.Path != first || len(repo.HostPaths) != 0 {
+			t.Errorf("path of the repository should not be changed, wont: %s, got: %s (%v)", first, repo.Path, repo.HostPaths)
+		}
+	})
+	defer os.Remove(databaseFile)
+}
+
+func TestAddContinuesOnFailures(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-add")
+	defer os.RemoveAll(dir)
+	git.PlainInit(filepath.Join(dir, "repo3"), false)
+	os.MkdirAll(filepath.Join(dir, "not-git"), 0755)
+	var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
+		var stderr = bytes.NewBuffer([]byte{})
+		var cmd = New()
+		cmd.SetOut(ioutil.Discard)
+		cmd.SetErr(stderr)
+		cmd.SetArgs([]string{"-g", "group2", filepath.Join(dir, "not-git"), filepath.Join(dir, "repo3")})
+		var err = cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "failed to add 1 repository") {
+			t.Errorf("failure should be reported, got: %v", err)
+		}
+		if !strings.Contains(stderr.String(), "not-git") {
+			t.Errorf("each failure should be printed, got: %s", stderr.String())
+		}
+		var db, _ = rrh.Open(config)
+		if !db.HasRelation("group2", "repo3") {
+			t.Errorf("the rest of the batch should be added")
+		}
+	})
+	defer os.Remove(databaseFile)
+}
+
+func TestAddRecursively(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-add")
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"repo3", "sub/repo4", "vendor/repo5"} {
+		git.PlainInit(filepath.Join(dir, name), false)
+	}
+	var testcases = []struct {
+		args      []string
+		hasError  bool
+		wontRepos map[string]bool
+	}{
+		{[]string{"--recursive", "-g", "group2", dir}, false, map[string]bool{"repo3": true, "repo4": true, "repo5": true}},
+		{[]string{"-R", "--max-depth", "1", "--ignore", "vendor", dir}, false, map[string]bool{"repo3": true, "repo4": false, "repo5": false}},
+		{[]string{"-R", "--repository-id", "id", dir}, true, map[string]bool{"repo3": false}},
+		{[]string{"-R", filepath.Join(dir, "not-exist")}, true, map[string]bool{"repo3": false}},
+	}
+	for _, tc := range testcases {
+		var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
+			var cmd = New()
+			cmd.SetOut(ioutil.Discard)
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			var db, _ = rrh.Open(config)
+			for repoID, exists := range tc.wontRepos {
+				if db.HasRepository(repoID) != exists {
+					t.Errorf("%v: existence of %s did not match, wont: %v", tc.args, repoID, exists)
+				}
+			}
+		})
+		defer os.Remove(databaseFile)
+	}
+}
+
+func TestAddWithAssignmentRules(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-add")
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "acme-repo")
+	var repo, _ = git.PlainInit(path, false)
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:acme/acme-repo.git"}})
+	os.Setenv(rrh.AssignmentRules, "owner:acme=work,owner:other=other")
+	defer os.Unsetenv(rrh.AssignmentRules)
+
+	var testcases = []struct {
+		args          []string
+		wontRelations map[string]bool
+	}{
+		{[]string{path}, map[string]bool{"work": true, "no-group": false, "other": false}},
+		{[]string{"-g", "group2", path}, map[string]bool{"work": true, "group2": true}},
+	}
+	for _, tc := range testcases {
+		var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, oldDB *rrh.Database) {
+			var cmd = New()
+			cmd.SetOut(ioutil.Discard)
+			cmd.SetArgs(tc.args)
+			if err := cmd.Execute(); err != nil {
+				t.Errorf("%v: add failed: %s", tc.args, err.Error())
+			}
+			var db, _ = rrh.Open(cfg)
+			for group, exists := range tc.wontRelations {
+				if db.HasRelation(group, "acme-repo") != exists {
+					t.Errorf("%v: relation %s/acme-repo wont %v", tc.args, group, exists)
+				}
+			}
+		})
+		defer os.Remove(databaseFile)
+	}
+}
+
+func TestRegisterWithoutAutoCreate(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-add")
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "acme-repo")
+	var repo, _ = git.PlainInit(path, false)
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:acme/acme-repo.git"}})
+	var databaseFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+		cfg.Update(rrh.AutoCreateGroup, "false")
+		if _, err := Register(db, "acme-repo", path, []string{"not-exist"}, true); err == nil {
+			t.Errorf("default group not existing should be error")
+		}
+		if db.HasRepository("acme-repo") {
+			t.Errorf("repository should not be registered without groups")
+		}
+		cfg.Update(rrh.AssignmentRules, "owner:acme=work")
+		var groups, err = Register(db, "acme-repo", path, []string{"not-exist"}, true)
+		if err != nil || strings.Join(groups, ",") != "work" {
+			t.Errorf("group of the assignment rule should be created and related, got: %v (%v)", groups, err)
+		}
+		if !db.HasRelation("work", "acme-repo") || db.HasGroup("not-exist") {
+			t.Errorf("only the group of the assignment rule should be related")
+		}
+	})
+	defer os.Remove(databaseFile)
+}
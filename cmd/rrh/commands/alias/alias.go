@@ -25,6 +25,12 @@ func New() *cobra.Command {
         alias --remove grlist
     execute
         type the registered alias name instead of rrh sub command`,
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if !aliasOpts.removeFlag && !(aliasOpts.updateFlag && len(args) == 0) {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return CompleteAliases(c, args, toComplete)
+		},
 		RunE: func(c *cobra.Command, args []string) error {
 			config := rrh.OpenConfig()
 			alias, err := LoadAliases(config)
@@ -73,6 +79,23 @@ func validateArgs(c *cobra.Command, args []string) error {
 	return nil
 }
 
+/*
+CompleteAliases completes the names of the registered aliases with their values as the descriptions.
+*/
+func CompleteAliases(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	aliasList, err := LoadAliases(rrh.OpenConfig())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	results := []string{}
+	for _, alias := range aliasList {
+		if strings.HasPrefix(alias.Name, toComplete) && !rrh.FindIn(alias.Name, args) {
+			results = append(results, fmt.Sprintf("%s\t%s", alias.Name, strings.Join(alias.Values, " ")))
+		}
+	}
+	return results, cobra.ShellCompDirectiveNoFileComp
+}
+
 func LoadAliases(config *rrh.Config) ([]*Command, error) {
 	path := config.GetValue(rrh.AliasPath)
 	alias := []*Command{}
@@ -27,18 +27,21 @@ func New() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringSliceVarP(&cloneOpts.groups, "groups", "g", []string{}, "specify the groups of the cloned repositories")
 	flags.StringVarP(&cloneOpts.directory, "directory", "d", ".", "specify the destination directory")
+	cmd.RegisterFlagCompletionFunc("groups", utils.CompleteGroups)
 	return cmd
 }
 
 var cloneOpts = &cloneOptions{}
 
 type cloneOptions struct {
-	groups    []string
-	directory string
+	groups     []string
+	directory  string
+	useDefault bool
 }
 
 func updateGroups(config *rrh.Config) {
-	if len(cloneOpts.groups) == 0 {
+	cloneOpts.useDefault = len(cloneOpts.groups) == 0
+	if cloneOpts.useDefault {
 		cloneOpts.groups = []string{config.GetValue(rrh.DefaultGroupName)}
 	}
 }
@@ -107,13 +110,22 @@ func doClone(args []string, db *rrh.Database) (int, error) {
 	return count, el.NilOrThis()
 }
 
+/*
+relateTo relates the repository to the given groups and the groups decided by the assignment rules.
+The default group is used only when no assignment rules match.
+*/
 func relateTo(db *rrh.Database, groupIDs []string, repoID string) error {
 	el := common.NewErrorList()
+	_, err := db.AssignGroups(repoID)
+	el = el.Append(err)
+	if cloneOpts.useDefault && len(db.FindAssignedGroups(db.FindRepository(repoID))) > 0 {
+		return el.NilOrThis()
+	}
 	for _, group := range groupIDs {
 		_, err := db.AutoCreateGroup(group, "", false)
 		el = el.Append(err)
 		if err == nil {
-			db.Relate(group, repoID)
+			el = el.Append(db.Relate(group, repoID))
 		}
 	}
 	return el.NilOrThis()
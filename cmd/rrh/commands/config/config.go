@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/common"
@@ -26,6 +28,9 @@ func newUnsetCommand() *cobra.Command {
 		Use:   "unset <KEYS...>",
 		Short: "unset the environment values of the given keys",
 		Args:  cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeLabels(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
 		RunE: func(c *cobra.Command, args []string) error {
 			return unsetConfig(c, args)
 		},
@@ -38,6 +43,14 @@ func newSetCommand() *cobra.Command {
 		Use:   "set <KEY> <VALUE>",
 		Short: "set the environment value with the given value",
 		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeLabels(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+			} else if len(args) == 1 {
+				return completeValues(args[0], toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
 		RunE: func(c *cobra.Command, args []string) error {
 			return setConfig(c, args)
 		},
@@ -45,6 +58,34 @@ func newSetCommand() *cobra.Command {
 	return cmd
 }
 
+func completeLabels(args []string, toComplete string) []string {
+	var results = []string{}
+	for _, label := range rrh.AvailableLabels {
+		if strings.HasPrefix(label, strings.ToUpper(toComplete)) && !rrh.FindIn(label, args) && label != rrh.ConfigPath {
+			results = append(results, label)
+		}
+	}
+	return results
+}
+
+/*
+completeValues completes the values of the label.
+If the label has no candidates, the file completion is performed, since the most of the labels are paths.
+*/
+func completeValues(label string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var candidates = rrh.ValueCandidates(label)
+	if len(candidates) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	var results = []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			results = append(results, candidate)
+		}
+	}
+	return results, cobra.ShellCompDirectiveNoFileComp
+}
+
 func newListCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -47,6 +47,7 @@ func ExampleConfigCommand() {
 	cmd.Execute()
 	// Output:
 	// RRH_ALIAS_PATH: ../../../../testdata/alias.json (default)
+	// RRH_ASSIGNMENT_RULES:  (default)
 	// RRH_AUTO_CREATE_GROUP: true (config_file)
 	// RRH_AUTO_DELETE_GROUP: false (config_file)
 	// RRH_CLONE_DESTINATION: . (default)
@@ -54,10 +55,18 @@ func ExampleConfigCommand() {
 	// RRH_CONFIG_PATH: ../../../../testdata/config.json (environment)
 	// RRH_DATABASE_PATH: ../../../../testdata/test_db.json (environment)
 	// RRH_DEFAULT_GROUP_NAME: no-group (default)
+	// RRH_EDITOR_COMMAND:  (default)
 	// RRH_ENABLE_COLORIZED: false (default)
+	// RRH_FORGES:  (default)
 	// RRH_HOME: ../../../../testdata/ (environment)
+	// RRH_HOOK_IGNORES:  (default)
+	// RRH_HOOK_MODE: offer (default)
+	// RRH_HOSTNAME:  (default)
+	// RRH_OPENERS:  (default)
 	// RRH_SORT_ON_UPDATING: true (config_file)
+	// RRH_TERMINAL_COMMAND:  (default)
 	// RRH_TIME_FORMAT: relative (default)
+	// RRH_WORKSPACE_ROOTS:  (default)
 }
 
 func ExampleConfigCommand_Run() {
@@ -70,6 +79,7 @@ func ExampleConfigCommand_Run() {
 	cmd.Execute()
 	// Output:
 	// RRH_ALIAS_PATH: ../../../../testdata/alias.json (default)
+	// RRH_ASSIGNMENT_RULES:  (default)
 	// RRH_AUTO_CREATE_GROUP: true (config_file)
 	// RRH_AUTO_DELETE_GROUP: false (config_file)
 	// RRH_CLONE_DESTINATION: . (default)
@@ -77,10 +87,18 @@ func ExampleConfigCommand_Run() {
 	// RRH_CONFIG_PATH: ../../../../testdata/config.json (environment)
 	// RRH_DATABASE_PATH: ../../../../testdata/database.json (environment)
 	// RRH_DEFAULT_GROUP_NAME: no-group (default)
+	// RRH_EDITOR_COMMAND:  (default)
 	// RRH_ENABLE_COLORIZED: false (default)
+	// RRH_FORGES:  (default)
 	// RRH_HOME: ../../../../testdata/ (environment)
+	// RRH_HOOK_IGNORES:  (default)
+	// RRH_HOOK_MODE: offer (default)
+	// RRH_HOSTNAME:  (default)
+	// RRH_OPENERS:  (default)
 	// RRH_SORT_ON_UPDATING: true (config_file)
+	// RRH_TERMINAL_COMMAND:  (default)
 	// RRH_TIME_FORMAT: relative (default)
+	// RRH_WORKSPACE_ROOTS:  (default)
 }
 func Example_listCommand_Run() {
 	os.Setenv(rrh.ConfigPath, "../../../../testdata/config.json")
@@ -92,6 +110,7 @@ func Example_listCommand_Run() {
 	cmd.Execute()
 	// Output:
 	// RRH_ALIAS_PATH: ../../../../testdata/alias.json (default)
+	// RRH_ASSIGNMENT_RULES:  (default)
 	// RRH_AUTO_CREATE_GROUP: true (config_file)
 	// RRH_AUTO_DELETE_GROUP: false (config_file)
 	// RRH_CLONE_DESTINATION: . (default)
@@ -99,10 +118,18 @@ func Example_listCommand_Run() {
 	// RRH_CONFIG_PATH: ../../../../testdata/config.json (environment)
 	// RRH_DATABASE_PATH: ../../../../testdata/database.json (default)
 	// RRH_DEFAULT_GROUP_NAME: no-group (default)
+	// RRH_EDITOR_COMMAND:  (default)
 	// RRH_ENABLE_COLORIZED: false (default)
+	// RRH_FORGES:  (default)
 	// RRH_HOME: ../../../../testdata/ (environment)
+	// RRH_HOOK_IGNORES:  (default)
+	// RRH_HOOK_MODE: offer (default)
+	// RRH_HOSTNAME:  (default)
+	// RRH_OPENERS:  (default)
 	// RRH_SORT_ON_UPDATING: true (config_file)
+	// RRH_TERMINAL_COMMAND:  (default)
 	// RRH_TIME_FORMAT: relative (default)
+	// RRH_WORKSPACE_ROOTS:  (default)
 }
 
 func TestLoadConfigFile(t *testing.T) {
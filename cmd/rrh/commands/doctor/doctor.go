@@ -0,0 +1,271 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+const (
+	missingPaths   = "missing-paths"
+	duplicatePaths = "duplicate-paths"
+	staleRemotes   = "stale-remotes"
+	dangling       = "dangling"
+	emptyGroups    = "empty-groups"
+	all            = "all"
+)
+
+type doctorOptions struct {
+	fixes []string
+}
+
+var doctorOpts = &doctorOptions{}
+
+/*
+issue represents the problem found in the database, and fix repairs it.
+*/
+type issue struct {
+	class   string
+	target  string
+	message string
+	fix     func(db *rrh.Database) error
+}
+
+type checker struct {
+	class string
+	check func(db *rrh.Database) []*issue
+}
+
+/*
+checkers are performed in this order, since the fix of the earlier class may cause the issues of the later class
+(e.g., removing the missing repositories makes the groups empty).
+*/
+var checkers = []checker{
+	{missingPaths, checkMissingPaths},
+	{duplicatePaths, checkDuplicatePaths},
+	{staleRemotes, checkStaleRemotes},
+	{dangling, checkDanglingRelations},
+	{emptyGroups, checkEmptyGroups},
+}
+
+func availableClasses() []string {
+	var classes = []string{}
+	for _, checker := range checkers {
+		classes = append(classes, checker.class)
+	}
+	return append(classes, all)
+}
+
+func New() *cobra.Command {
+	doctorCommand := &cobra.Command{
+		Use:   "doctor",
+		Short: "check the integrity of the rrh database",
+		Long: `check the integrity of the rrh database, and fix the found issues.
+The classes of the issues are as follows.
+    missing-paths:   the paths of the repositories do not exist, or are not git repositories.
+                     fix: removes the repositories.
+    duplicate-paths: the repositories have the same path.
+                     fix: merges the relations to the first repository, and removes the others.
+    stale-remotes:   the remotes of the repositories differ from .git/config.
+                     fix: updates the remotes from .git/config.
+    dangling:        the relations point the unknown repositories or groups.
+                     fix: removes the relations.
+    empty-groups:    the groups have no repositories and no child groups.
+                     fix: removes the groups.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			return utils.ValidateValues(doctorOpts.fixes, availableClasses())
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	flags := doctorCommand.Flags()
+	flags.StringSliceVarP(&doctorOpts.fixes, "fix", "f", []string{}, "fixes the issues of the given classes (missing-paths, duplicate-paths, stale-remotes, dangling, empty-groups, and all)")
+
+	doctorCommand.RegisterFlagCompletionFunc("fix", utils.CompleteValues(availableClasses()...))
+	return doctorCommand
+}
+
+func isFixTarget(class string) bool {
+	return rrh.FindIn(all, doctorOpts.fixes) || rrh.FindIn(class, doctorOpts.fixes)
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	var found, fixed = 0, 0
+	for _, checker := range checkers {
+		for _, issue := range checker.check(db) {
+			found++
+			if !isFixTarget(issue.class) {
+				c.Printf("%s: %s (%s)\n", issue.target, issue.message, issue.class)
+				continue
+			}
+			if err := issue.fix(db); err != nil {
+				c.Printf("%s: %s (%s), fix failed: %s\n", issue.target, issue.message, issue.class, err.Error())
+				continue
+			}
+			fixed++
+			c.Printf("%s: %s (%s), fixed\n", issue.target, issue.message, issue.class)
+		}
+	}
+	printSummary(c, found, fixed)
+	if fixed > 0 {
+		return db.StoreAndClose()
+	}
+	return nil
+}
+
+func printSummary(c *cobra.Command, found, fixed int) {
+	if found == 0 {
+		c.Println("No issues found")
+		return
+	}
+	c.Printf("Found %s", english.Plural(found, "issue", ""))
+	if fixed > 0 {
+		c.Printf(", and fixed %d", fixed)
+	}
+	c.Println()
+}
+
+func deleteRepository(repoID string) func(db *rrh.Database) error {
+	return func(db *rrh.Database) error {
+		return db.DeleteRepository(repoID)
+	}
+}
+
+func checkMissingPaths(db *rrh.Database) []*issue {
+	var issues = []*issue{}
+	var host = rrh.CurrentHostname(db.Config)
+	for _, repo := range db.Repositories {
+		if !repo.IsAvailableOn(host) {
+			// the path not pointing the location on this host could not be checked.
+			continue
+		}
+		if _, err := os.Stat(repo.Path); os.IsNotExist(err) {
+			issues = append(issues, &issue{missingPaths, repo.ID, "path not found: " + repo.Path, deleteRepository(repo.ID)})
+		} else if !rrh.IsGitRepository(repo.Path) {
+			issues = append(issues, &issue{missingPaths, repo.ID, "not a git repository: " + repo.Path, deleteRepository(repo.ID)})
+		}
+	}
+	return issues
+}
+
+func checkDuplicatePaths(db *rrh.Database) []*issue {
+	var issues = []*issue{}
+	var owners = map[string]string{}
+	var host = rrh.CurrentHostname(db.Config)
+	for _, repo := range db.Repositories {
+		if !repo.IsAvailableOn(host) {
+			continue
+		}
+		var path = filepath.Clean(repo.Path)
+		var owner, ok = owners[path]
+		if !ok {
+			owners[path] = repo.ID
+			continue
+		}
+		issues = append(issues, &issue{duplicatePaths, repo.ID, "same path with " + owner, mergeRepository(repo.ID, owner)})
+	}
+	return issues
+}
+
+func mergeRepository(from, to string) func(db *rrh.Database) error {
+	return func(db *rrh.Database) error {
+		for _, groupName := range db.FindRelationsOfRepository(from) {
+			if group := db.FindGroup(groupName); group == nil || group.IsDynamic() {
+				continue
+			}
+			if err := db.Relate(groupName, to); err != nil {
+				return err
+			}
+		}
+		return db.DeleteRepository(from)
+	}
+}
+
+func checkStaleRemotes(db *rrh.Database) []*issue {
+	var issues = []*issue{}
+	var host = rrh.CurrentHostname(db.Config)
+	for _, repo := range db.Repositories {
+		if !repo.IsAvailableOn(host) {
+			continue
+		}
+		var remotes, err = rrh.FindRemotes(repo.Path)
+		if err != nil || isSameRemotes(repo.Remotes, remotes) {
+			continue
+		}
+		issues = append(issues, &issue{staleRemotes, repo.ID, "remotes differ from .git/config", updateRemotes(repo, remotes)})
+	}
+	return issues
+}
+
+func isSameRemotes(remotes1, remotes2 []*rrh.Remote) bool {
+	if len(remotes1) != len(remotes2) {
+		return false
+	}
+	for _, r1 := range remotes1 {
+		if !containsRemote(remotes2, r1) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsRemote(remotes []*rrh.Remote, remote *rrh.Remote) bool {
+	for _, r := range remotes {
+		if r.Name == remote.Name && r.URL == remote.URL {
+			return true
+		}
+	}
+	return false
+}
+
+func updateRemotes(repo *rrh.Repository, remotes []*rrh.Remote) func(db *rrh.Database) error {
+	return func(db *rrh.Database) error {
+		repo.Remotes = remotes
+		return nil
+	}
+}
+
+func checkDanglingRelations(db *rrh.Database) []*issue {
+	var issues = []*issue{}
+	for _, relation := range db.Relations {
+		if !db.HasRepository(relation.RepositoryID) {
+			issues = append(issues, &issue{dangling, relation.String(), "repository not found", removeRelation(relation)})
+		} else if !db.HasGroup(relation.GroupName) {
+			issues = append(issues, &issue{dangling, relation.String(), "group not found", removeRelation(relation)})
+		}
+	}
+	return issues
+}
+
+func removeRelation(relation *rrh.Relation) func(db *rrh.Database) error {
+	return func(db *rrh.Database) error {
+		var relations = []*rrh.Relation{}
+		for _, r := range db.Relations {
+			if r != relation {
+				relations = append(relations, r)
+			}
+		}
+		db.Relations = relations
+		return nil
+	}
+}
+
+func checkEmptyGroups(db *rrh.Database) []*issue {
+	var issues = []*issue{}
+	for _, group := range db.Groups {
+		if group.IsDynamic() || db.ContainsCount(group.Name) > 0 || len(db.FindChildGroups(group.Name)) > 0 {
+			continue
+		}
+		var name = group.Name
+		issues = append(issues, &issue{emptyGroups, name, "group has no repositories", func(db *rrh.Database) error {
+			return db.DeleteGroup(name)
+		}})
+	}
+	return issues
+}
@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+)
+
+func createGitRepository(t *testing.T) string {
+	var dir, err = ioutil.TempDir("", "rrh-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/example/doctor.git"}})
+	return dir
+}
+
+func TestDoctor(t *testing.T) {
+	var dir = createGitRepository(t)
+	defer os.RemoveAll(dir)
+	var testcases = []struct {
+		args       []string
+		wontError  bool
+		wontOutput string
+		repoExists map[string]bool
+		relations  []string
+	}{
+		{[]string{}, false, "Found 6 issues", map[string]bool{"repo1": true, "dup": true}, []string{"group1/ghost", "group2/dup"}},
+		{[]string{"--fix", "dangling,duplicate-paths"}, false, "Found 5 issues, and fixed 2", map[string]bool{"repo1": true, "dup": false}, []string{"group2/doctor"}},
+		{[]string{"--fix", "all"}, false, "Found 7 issues, and fixed 7", map[string]bool{"repo1": false, "doctor": true}, []string{"group2/doctor"}},
+		{[]string{"--fix", "unknown"}, true, "", map[string]bool{}, []string{}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("doctor", dir, "", []*rrh.Remote{})
+			db.CreateRepository("dup", dir, "", []*rrh.Remote{})
+			db.Relate("group2", "dup")
+			db.Relations = append(db.Relations, &rrh.Relation{RepositoryID: "ghost", GroupName: "group1"})
+			db.StoreAndClose()
+
+			var out = new(bytes.Buffer)
+			var cmd = New()
+			cmd.SetOut(out)
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.wontError {
+				t.Errorf("%v: wont error %v, but got %v", tc.args, tc.wontError, err)
+			}
+			if !strings.Contains(out.String(), tc.wontOutput) {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.wontOutput, out.String())
+			}
+			var db2, _ = rrh.Open(config)
+			for repoID, exists := range tc.repoExists {
+				if db2.HasRepository(repoID) != exists {
+					t.Errorf("%v: existence of %s did not match, wont: %v", tc.args, repoID, exists)
+				}
+			}
+			for _, relation := range tc.relations {
+				var items = strings.Split(relation, "/")
+				if !hasRelation(db2, items[0], items[1]) {
+					t.Errorf("%v: relation %s not found", tc.args, relation)
+				}
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func hasRelation(db *rrh.Database, groupName, repoID string) bool {
+	for _, relation := range db.Relations {
+		if relation.GroupName == groupName && relation.RepositoryID == repoID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDoctorSkipsUnavailableRepositories(t *testing.T) {
+	os.Setenv(rrh.Hostname, "this-host")
+	defer os.Unsetenv(rrh.Hostname)
+	var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		db.CreateRepository("unresolved-host", "/not/exist/unresolved-host", "", []*rrh.Remote{})
+		db.FindRepository("unresolved-host").HostPaths = []*rrh.HostPath{{Host: "this-host", Root: "undefined", Path: "tamada/host"}}
+		db.CreateRepository("dup", "/not/exist/unresolved-host", "", []*rrh.Remote{})
+		db.FindRepository("dup").HostPaths = []*rrh.HostPath{{Host: "this-host", Root: "undefined", Path: "tamada/dup"}}
+		db.StoreAndClose()
+
+		var db2, _ = rrh.Open(config)
+		for _, issues := range [][]*issue{checkMissingPaths(db2), checkDuplicatePaths(db2), checkStaleRemotes(db2)} {
+			for _, issue := range issues {
+				if issue.target == "unresolved-host" || issue.target == "dup" {
+					t.Errorf("%s: repository not available on this host should not be checked, got: %s", issue.target, issue.message)
+				}
+			}
+		}
+	})
+	defer os.Remove(dbFile)
+}
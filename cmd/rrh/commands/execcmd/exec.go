@@ -10,6 +10,8 @@ import (
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
 	"github.com/tamada/rrh/common"
+	"github.com/tamada/rrh/filter"
+	"github.com/tamada/rrh/selector"
 )
 
 func New() *cobra.Command {
@@ -23,9 +25,13 @@ func New() *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringSliceVarP(&execOpts.repositories, "repositories", "r", []string{}, "specify the target repositories")
-	flags.StringSliceVarP(&execOpts.groups, "groups", "g", []string{}, "specify the target group")
+	flags.StringSliceVarP(&execOpts.groups, "groups", "g", []string{}, "specify the target group selectors, such as work,!legacy, go&backend, and team-* (including the descendant groups)")
+	flags.StringSliceVarP(&execOpts.tags, "tag", "t", []string{}, "specify the tag selectors (KEY, KEY=VALUE, or !KEY) of the target repositories")
+	flags.StringVarP(&execOpts.filter, "filter", "F", "", "specify the filter expression of the target repositories (e.g., remote =~ \"github.com/acme\" && dirty)")
 	flags.BoolVarP(&execOpts.withoutHeader, "no-header", "H", false, "print without header")
 	flags.BoolVarP(&execOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	cmd.RegisterFlagCompletionFunc("repositories", utils.CompleteRepositories)
+	cmd.RegisterFlagCompletionFunc("groups", utils.CompleteGroups)
 	return cmd
 }
 
@@ -33,28 +39,35 @@ func validateArguments(c *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return errors.New("some command should be specified")
 	}
-	if len(execOpts.repositories) == 0 && len(execOpts.groups) == 0 {
-		return errors.New("either of repositories and groups options should be specified")
+	if len(execOpts.repositories) == 0 && len(execOpts.groups) == 0 && len(execOpts.tags) == 0 && execOpts.filter == "" {
+		return errors.New("either of repositories, groups, tag, and filter options should be specified")
 	}
-	return nil
+	f, err := filter.Compile(execOpts.filter)
+	if err != nil {
+		return err
+	}
+	execOpts.compiledFilter = f
+	return rrh.ValidateTagSelectors(execOpts.tags)
 }
 
 var execOpts = &execOptions{}
 
 type execOptions struct {
-	repositories  []string
-	groups        []string
-	withoutHeader bool
-	dryRunFlag    bool
+	repositories   []string
+	groups         []string
+	tags           []string
+	filter         string
+	compiledFilter *filter.Filter
+	withoutHeader  bool
+	dryRunFlag     bool
 }
 
-func findRelatedRepositories(groups []string, db *rrh.Database) []string {
-	repos := []string{}
-	for _, group := range groups {
-		r := db.FindRelationsOfGroup(group)
-		repos = append(repos, r...)
+func findRelatedRepositories(groups []string, db *rrh.Database) ([]string, error) {
+	result, err := selector.Resolve(db, groups)
+	if err != nil {
+		return nil, err
 	}
-	return eliminateDuplication(repos)
+	return result.Repositories, nil
 }
 
 func eliminateDuplication(froms []string) []string {
@@ -81,16 +94,45 @@ func validateRepos(repos []string, db *rrh.Database) ([]string, error) {
 	return results, el.NilOrThis()
 }
 
+func findAllRepositories(db *rrh.Database) []string {
+	repos := []string{}
+	for _, repo := range db.Repositories {
+		repos = append(repos, repo.ID)
+	}
+	return repos
+}
+
+func filterByTags(repos []string, db *rrh.Database) []string {
+	results := []string{}
+	for _, r := range repos {
+		repo := db.FindRepository(r)
+		if repo != nil && repo.MatchTags(execOpts.tags) {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
 func findTargetRepositories(db *rrh.Database) ([]string, error) {
 	repos := []string{}
 	if len(execOpts.groups) > 0 {
-		repos2 := findRelatedRepositories(execOpts.groups, db)
+		repos2, err := findRelatedRepositories(execOpts.groups, db)
+		if err != nil {
+			return nil, err
+		}
 		repos = append(repos, repos2...)
 	}
 	if len(execOpts.repositories) > 0 {
 		repos = append(repos, execOpts.repositories...)
 	}
-	return validateRepos(repos, db)
+	if len(execOpts.groups) == 0 && len(execOpts.repositories) == 0 {
+		repos = findAllRepositories(db)
+	}
+	results, err := validateRepos(eliminateDuplication(repos), db)
+	if err != nil {
+		return nil, err
+	}
+	return execOpts.compiledFilter.Apply(db, filterByTags(results, db))
 }
 
 func execute(c *cobra.Command, repo *rrh.Repository, args []string) error {
@@ -118,6 +160,12 @@ func performExec(c *cobra.Command, args []string, db *rrh.Database) error {
 	for _, repository := range repositories {
 		repo := db.FindRepository(repository)
 		el.Append(execute(c, repo, args))
+		if !execOpts.dryRunFlag {
+			db.Touch(repo.ID)
+		}
+	}
+	if !execOpts.dryRunFlag {
+		el = el.Append(db.StoreAndClose())
 	}
 	return el.NilOrThis()
 }
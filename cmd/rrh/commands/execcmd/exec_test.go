@@ -2,6 +2,7 @@ package execcmd
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
@@ -29,6 +30,10 @@ func TestValidateArguments(t *testing.T) {
 		{&execOptions{}, []string{"ls"}, true},
 		{&execOptions{groups: []string{"group1"}}, []string{}, true},
 		{&execOptions{repositories: []string{"repo1"}}, []string{"ls"}, false},
+		{&execOptions{tags: []string{"lang=go"}}, []string{"ls"}, false},
+		{&execOptions{tags: []string{"=go"}}, []string{"ls"}, true},
+		{&execOptions{filter: `group == "group1"`}, []string{"ls"}, false},
+		{&execOptions{filter: `grop == "group1"`}, []string{"ls"}, true},
 	}
 	for _, td := range testdata {
 
@@ -39,3 +44,35 @@ func TestValidateArguments(t *testing.T) {
 		}
 	}
 }
+
+func TestFindTargetRepositoriesWithTags(t *testing.T) {
+	testdata := []struct {
+		execOpts *execOptions
+		wontRepo []string
+	}{
+		{&execOptions{tags: []string{"lang=go"}}, []string{"repo2"}},
+		{&execOptions{tags: []string{"!lang"}}, []string{"repo1"}},
+		{&execOptions{groups: []string{"group1", "group3"}, tags: []string{"lang"}}, []string{"repo2"}},
+		{&execOptions{repositories: []string{"repo1"}, tags: []string{"lang"}}, []string{}},
+		{&execOptions{filter: `remote =~ "github.com/example"`}, []string{"repo2"}},
+		{&execOptions{groups: []string{"group1", "group3"}, filter: `!tag("lang")`}, []string{"repo1"}},
+		{&execOptions{groups: []string{"group*", "!group3"}}, []string{"repo1"}},
+	}
+	for _, td := range testdata {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.TagRepository("repo2", []*rrh.Tag{{Key: "lang", Value: "go"}})
+			execOpts = td.execOpts
+			if err := validateArguments(nil, []string{"ls"}); err != nil {
+				t.Errorf("%v: unexpected validation error: %s", td.execOpts, err.Error())
+			}
+			repos, err := findTargetRepositories(db)
+			if err != nil {
+				t.Errorf("%v: unexpected error: %s", td.execOpts, err.Error())
+			}
+			if strings.Join(repos, ",") != strings.Join(td.wontRepo, ",") {
+				t.Errorf("%v: target repositories did not match, wont: %v, got: %v", td.execOpts, td.wontRepo, repos)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
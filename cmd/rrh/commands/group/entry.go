@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/formatter"
 )
 
 type Entries int
@@ -15,7 +16,9 @@ const (
 	groupDesc               = 4
 	repositories            = 8
 	abbrevFlag              = 16
-	groupAll                = groupName | groupDesc | repositories | abbrevFlag
+	parentGroup             = 32
+	groupRules              = 64
+	groupAll                = groupName | groupDesc | repositories | abbrevFlag | parentGroup | groupRules
 )
 
 func NewEntries(entries []string) (Entries, error) {
@@ -24,9 +27,9 @@ func NewEntries(entries []string) (Entries, error) {
 		switch strings.ToLower(entry) {
 		case "all":
 			result |= groupAll
-		case "name":
+		case "name", "group":
 			result |= groupName
-		case "repo":
+		case "repo", "id":
 			result |= repositories
 		case "note":
 			result |= groupDesc
@@ -34,8 +37,12 @@ func NewEntries(entries []string) (Entries, error) {
 			result |= abbrevFlag
 		case "count":
 			result |= repositoryCount
+		case "parent":
+			result |= parentGroup
+		case "rules":
+			result |= groupRules
 		default:
-			return 0, fmt.Errorf("%s: invalid entry, availables are: all, name, desc, repo, abbrev and count", entry)
+			return 0, fmt.Errorf("%s: invalid entry, availables are: %s", entry, strings.Join(availableEntries, ", "))
 		}
 	}
 	return result, nil
@@ -58,12 +65,53 @@ func (ge Entries) StringArray() []string {
 	if ge.IsCount() {
 		headers = append(headers, "repository count")
 	}
+	if ge.IsParent() {
+		headers = append(headers, "parent")
+	}
+	if ge.IsRules() {
+		headers = append(headers, "rules")
+	}
 	return headers
 }
 
+/*
+Columns returns the columns of the table formats (formatter.Table) for the entries, in the same order of StringArray.
+The keys are the same as the headers, for the compatibility with the json format of the previous versions.
+*/
+func (ge Entries) Columns() []*formatter.Column {
+	columns := []*formatter.Column{}
+	if ge.IsName() {
+		columns = append(columns, formatter.NewColumn("Group", "name", "group"))
+	}
+	if ge.IsDesc() {
+		columns = append(columns, formatter.NewColumn("Note", "note", "note"))
+	}
+	if ge.IsAbbrev() {
+		columns = append(columns, formatter.NewColumn("Abbrev", "abbrev", "abbrev"))
+	}
+	if ge.IsRepo() {
+		columns = append(columns, formatter.NewColumn("Repositories", "repositories", "repositories"))
+	}
+	if ge.IsCount() {
+		columns = append(columns, formatter.NewColumn("Count", "repository count", "repository count"))
+	}
+	if ge.IsParent() {
+		columns = append(columns, formatter.NewColumn("Parent", "parent", "parent"))
+	}
+	if ge.IsRules() {
+		columns = append(columns, formatter.NewColumn("Rules", "rules", "rules"))
+	}
+	return columns
+}
+
+/*
+availableEntries is the entries of group list command.
+The group and the id are the aliases of the name and the repo, for the consistency with the entries of list command.
+*/
+var availableEntries = []string{"all", "name", "group", "count", "note", "repo", "id", "abbrev", "parent", "rules"}
+
 func ValidateEntries(entries []string) error {
-	availables := []string{"all", "name", "count", "note", "repo", "abbrev"}
-	return utils.ValidateValues(entries, availables)
+	return utils.ValidateValues(entries, availableEntries)
 }
 
 func (ge Entries) IsName() bool {
@@ -85,3 +133,11 @@ func (ge Entries) IsRepo() bool {
 func (ge Entries) IsAbbrev() bool {
 	return ge&abbrevFlag == abbrevFlag
 }
+
+func (ge Entries) IsParent() bool {
+	return ge&parentGroup == parentGroup
+}
+
+func (ge Entries) IsRules() bool {
+	return ge&groupRules == groupRules
+}
@@ -12,11 +12,13 @@ func TestGroupEntriesTest(t *testing.T) {
 		iserror bool
 		array   []string
 	}{
-		{[]string{"all"}, false, []string{"name", "note", "abbrev", "repositories"}},
+		{[]string{"all"}, false, []string{"name", "note", "abbrev", "repositories", "parent", "rules"}},
 		{[]string{"name", "note"}, false, []string{"name", "note"}},
 		{[]string{"hoge"}, true, []string{}},
 		{[]string{"hoge", "fuga"}, true, []string{}},
 		{[]string{"name", "note", "abbrev", "repo", "count"}, false, []string{"name", "note", "abbrev", "repositories", "repository count"}},
+		{[]string{"parent"}, false, []string{"parent"}},
+		{[]string{"rules"}, false, []string{"rules"}},
 	}
 	for _, td := range testdata {
 		err := ValidateEntries(td.args)
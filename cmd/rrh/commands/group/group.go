@@ -19,6 +19,7 @@ func New() *cobra.Command {
 
 func registerGroupCommands(c *cobra.Command) {
 	c.AddCommand(createGroupAddCommand())
+	c.AddCommand(createGroupAutoCommand())
 	c.AddCommand(createGroupInfoCommand())
 	c.AddCommand(createGroupOfCommand())
 	c.AddCommand(createGroupListCommand())
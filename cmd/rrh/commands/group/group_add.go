@@ -1,7 +1,9 @@
 package group
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
@@ -11,6 +13,8 @@ import (
 type createOptions struct {
 	desc       string
 	abbrevFlag string
+	parent     string
+	rules      []string
 	dryRunFlag bool
 }
 
@@ -20,7 +24,13 @@ func createGroupAddCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "add <GROUP_NAME>",
 		Short: "add groups to the rrh database",
-		Args:  cobra.ExactArgs(1),
+		Long: `add groups to the rrh database.
+The group with --rule is the dynamic group, whose repositories are computed from the rules (FIELD:PATTERN).
+The available fields are remote (e.g., remote:github.com/acme/*), owner (e.g., owner:acme),
+path (e.g., path:~/src/work), tag (e.g., tag:lang=go), id (e.g., id:rrh*), and file (e.g., file:go.mod).
+The repositories must satisfy all of the rules.
+The dynamic groups are read-only, that is, the repositories could not be added to/removed from them.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, func(c *cobra.Command, args []string, db *rrh.Database) error {
 				if err := createAndAddGroup(c, args[0], db); err != nil {
@@ -29,7 +39,7 @@ func createGroupAddCommand() *cobra.Command {
 				if !createOpts.dryRunFlag {
 					return db.StoreAndClose()
 				}
-				c.Printf("create group Name: %s, Description: %s, Abbrev: %v\n", args[0], createOpts.desc, createOpts.abbrevFlag)
+				c.Printf("create group Name: %s, Description: %s, Abbrev: %v, Parent: %s, Rules: %s\n", args[0], createOpts.desc, createOpts.abbrevFlag, createOpts.parent, strings.Join(createOpts.rules, ", "))
 				return nil
 			})
 		},
@@ -37,6 +47,8 @@ func createGroupAddCommand() *cobra.Command {
 	flags := command.Flags()
 	flags.StringVarP(&createOpts.desc, "note", "n", "", "specifies the note")
 	flags.StringVarP(&createOpts.abbrevFlag, "abbrev", "a", "false", "set the group as the abbrev")
+	flags.StringVarP(&createOpts.parent, "parent", "p", "", "specifies the parent group")
+	flags.StringArrayVarP(&createOpts.rules, "rule", "r", []string{}, "specifies the rule of the dynamic group (FIELD:PATTERN)")
 	flags.BoolVarP(&createOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
 	return command
 }
@@ -46,6 +58,15 @@ func createAndAddGroup(c *cobra.Command, groupName string, db *rrh.Database) err
 	if err != nil {
 		return err
 	}
-	_, err = db.CreateGroup(groupName, createOpts.desc, abbrevFlag)
-	return err
+	if createOpts.parent != "" && !db.HasGroup(createOpts.parent) {
+		return fmt.Errorf("%s: parent group not found", createOpts.parent)
+	}
+	if _, err = db.CreateGroup(groupName, createOpts.desc, abbrevFlag); err != nil {
+		return err
+	}
+	if err = db.SetGroupRules(groupName, createOpts.rules); err != nil {
+		db.DeleteGroup(groupName)
+		return err
+	}
+	return db.SetParentGroup(groupName, createOpts.parent)
 }
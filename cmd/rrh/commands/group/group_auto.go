@@ -0,0 +1,181 @@
+package group
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/common"
+)
+
+type autoOptions struct {
+	dryRunFlag bool
+	by         string
+}
+
+var autoOpts = &autoOptions{}
+
+/*
+autoGroupers derive the group name of the repository for --by option.
+The empty name means the group could not be derived (e.g., the repository has no remotes).
+*/
+var autoGroupers = map[string]func(repo *rrh.Repository) string{
+	"host":       func(repo *rrh.Repository) string { return primaryRemoteURL(repo).Host },
+	"owner":      ownerOf,
+	"host/owner": hostAndOwnerOf,
+	"parent-dir": parentDirOf,
+}
+
+var availableGroupers = []string{"host", "owner", "host/owner", "parent-dir"}
+
+func primaryRemoteURL(repo *rrh.Repository) *rrh.RemoteURL {
+	var remote = repo.PrimaryRemote()
+	if remote == nil {
+		return &rrh.RemoteURL{}
+	}
+	return rrh.ParseRemoteURL(remote.URL)
+}
+
+func ownerOf(repo *rrh.Repository) string {
+	var url = primaryRemoteURL(repo)
+	if url.Host == "" {
+		return ""
+	}
+	return url.Owner()
+}
+
+func parentDirOf(repo *rrh.Repository) string {
+	var name = filepath.Base(filepath.Dir(repo.Path))
+	if name == "." || name == string(filepath.Separator) {
+		return ""
+	}
+	return name
+}
+
+/*
+hostAndOwnerOf joins the host and the owner with ":" like the scp-like form of the remote url,
+since "/" is the separator of the group hierarchy (rrh.GroupPathSeparator).
+*/
+func hostAndOwnerOf(repo *rrh.Repository) string {
+	var owner = ownerOf(repo)
+	if owner == "" {
+		return ""
+	}
+	return primaryRemoteURL(repo).Host + ":" + owner
+}
+
+func createGroupAutoCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "auto [REPOSITORY_IDs...]",
+		Short: "assign the groups to the repositories by the assignment rules, the remotes, or the paths",
+		Long: `assign the groups to the repositories by the assignment rules (RRH_ASSIGNMENT_RULES).
+The assignment rule is RULE[&RULE...]=GROUP form, and the rules are comma separated,
+e.g., "owner:acme=work,path:~/src/oss=oss,file:go.mod=go".
+The form of RULE is the same as the rule of the dynamic group (see "rrh group add --help").
+With --by option, the groups are derived from the primary remote (origin, or the first remote)
+or the path of the repositories instead of the rules.
+    host:       the host of the remote (e.g., github.com).
+    owner:      the owner of the remote (e.g., tamada of github.com/tamada/rrh).
+    host/owner: the host and the owner of the remote, joined by ":" (e.g., github.com:tamada).
+    parent-dir: the name of the parent directory of the repository.
+The groups not existing are created. If no repositories are given, all repositories are the targets.`,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			if autoOpts.by == "" {
+				return nil
+			}
+			return utils.ValidateValue(autoOpts.by, availableGroupers)
+		},
+		ValidArgsFunction: utils.CompleteRepositories,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, executeGroupAuto)
+		},
+	}
+	flags := c.Flags()
+	flags.BoolVarP(&autoOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	flags.StringVarP(&autoOpts.by, "by", "b", "", "derive the groups from host, owner, host/owner, or parent-dir instead of the assignment rules")
+
+	c.RegisterFlagCompletionFunc("by", utils.CompleteValues(availableGroupers...))
+	return c
+}
+
+func findAutoTargets(db *rrh.Database, args []string) ([]*rrh.Repository, error) {
+	if len(args) == 0 {
+		return db.Repositories, nil
+	}
+	var el = common.NewErrorList()
+	var results = []*rrh.Repository{}
+	for _, arg := range args {
+		if repo := db.FindRepository(arg); repo != nil {
+			results = append(results, repo)
+		} else {
+			el = el.Append(fmt.Errorf("%s: repository not found", arg))
+		}
+	}
+	return results, el.NilOrThis()
+}
+
+func findAutoGroups(db *rrh.Database, repo *rrh.Repository) []string {
+	if autoOpts.by == "" {
+		return db.FindAssignedGroups(repo)
+	}
+	if name := autoGroupers[strings.ToLower(autoOpts.by)](repo); name != "" {
+		return []string{name}
+	}
+	return []string{}
+}
+
+func findNewGroups(db *rrh.Database, repo *rrh.Repository, groups []string) []string {
+	var results = []string{}
+	for _, name := range groups {
+		if group := db.FindGroup(name); group != nil && group.IsDynamic() {
+			continue
+		}
+		if !db.HasRelation(name, repo.ID) {
+			results = append(results, name)
+		}
+	}
+	return results
+}
+
+func executeGroupAuto(c *cobra.Command, args []string, db *rrh.Database) error {
+	if autoOpts.by == "" && len(db.Config.AssignmentRules()) == 0 {
+		return fmt.Errorf("no assignment rules, set %s", rrh.AssignmentRules)
+	}
+	var repos, err = findAutoTargets(db, args)
+	if err != nil {
+		return err
+	}
+	var count = 0
+	for _, repo := range repos {
+		var groups = findNewGroups(db, repo, findAutoGroups(db, repo))
+		if len(groups) == 0 {
+			continue
+		}
+		if !autoOpts.dryRunFlag {
+			var related, err = db.RelateToGroups(repo.ID, groups)
+			if err != nil {
+				c.PrintErrln(err.Error())
+			}
+			if len(related) == 0 {
+				continue
+			}
+			groups = related
+		}
+		count++
+		c.Printf("%s: %s%s\n", db.Config.Decorator.RepositoryID(repo.ID), strings.Join(groups, ", "), autoDryRunMode())
+	}
+	if count > 0 && !autoOpts.dryRunFlag {
+		return db.StoreAndClose()
+	}
+	return nil
+}
+
+func autoDryRunMode() string {
+	if autoOpts.dryRunFlag {
+		return " (dry-run mode)"
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package group
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/spf13/cobra"
@@ -12,9 +13,10 @@ import (
 
 func createGroupInfoCommand() *cobra.Command {
 	command := &cobra.Command{
-		Use:   "info <GROUP>",
-		Short: "print the information of the specified group",
-		Args:  cobra.MinimumNArgs(1),
+		Use:               "info <GROUP>",
+		Short:             "print the information of the specified group",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: utils.CompleteGroups,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, printGroupInfos)
 		},
@@ -39,5 +41,12 @@ func printGroupInfo(c *cobra.Command, groupName string, db *rrh.Database) error
 	count := db.ContainsCount(group.Name)
 	decoratedName := db.Config.Decorator.GroupName(group.Name)
 	c.Printf("%s: %s (%s, abbrev: %v)\n", decoratedName, group.Description, english.Plural(count, "repository", ""), group.OmitList)
+	if !group.IsRoot() || len(db.FindChildGroups(group.Name)) > 0 {
+		total := db.ContainsCountRecursively(group.Name)
+		c.Printf("    path: %s (%s in the subtree)\n", db.GroupPath(group.Name), english.Plural(total, "repository", ""))
+	}
+	if group.IsDynamic() {
+		c.Printf("    rules: %s\n", strings.Join(group.Rules, ", "))
+	}
 	return nil
 }
@@ -1,13 +1,13 @@
 package group
 
 import (
-	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/formatter"
 )
 
 type groupEntry int
@@ -25,6 +25,8 @@ type listOptions struct {
 	format  string
 	entries []string
 	header  bool
+	sortKey string
+	reverse bool
 }
 
 var listOpts = &listOptions{}
@@ -42,9 +44,13 @@ func createGroupListCommand() *cobra.Command {
 		},
 	}
 	flags := command.Flags()
-	flags.StringVarP(&listOpts.format, "format", "f", "default", "specifies the output format. Available values: csv, json, and table.")
-	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"name", "count"}, "specifies the printing entries separated with comma. Available vaues: all, name, note, repo, abbrev, and count")
+	flags.StringVarP(&listOpts.format, "format", "f", "default", "specifies the output format. Available values: default, table, csv, markdown, json, ndjson, yaml, and template=TEMPLATE (e.g., template='{{.Group}}\\t{{.Count}}')")
+	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"name", "count"}, "specifies the printing entries separated with comma. Available vaues: all, name (group), note, repo (id), abbrev, count, parent, and rules")
+	flags.StringVarP(&listOpts.sortKey, "sort", "s", "", "specifies the sort key of the groups.\navailables: id, path, group, added-at, updated-at, last-accessed, last-commit, and size (group sorts by the group names, and the others sort by the first repository of each group in the order of the key)")
+	flags.BoolVarP(&listOpts.reverse, "reverse", "r", false, "reverses the sorting order")
 	flags.BoolVarP(&listOpts.header, "no-header", "H", false, "print without headers")
+	command.RegisterFlagCompletionFunc("format", utils.CompleteValues(formatter.Formats...))
+	command.RegisterFlagCompletionFunc("sort", utils.CompleteValues(rrh.RepositorySortKeys...))
 
 	return command
 }
@@ -54,7 +60,13 @@ func validateListOpts(opts *listOptions) error {
 	if err != nil {
 		return err
 	}
-	return ValidateFormatter(opts.format)
+	if err := formatter.Validate(opts.format, formatter.Formats); err != nil {
+		return err
+	}
+	if opts.sortKey != "" {
+		return utils.ValidateValue(opts.sortKey, rrh.RepositorySortKeys)
+	}
+	return nil
 }
 
 func listGroups(c *cobra.Command, args []string, db *rrh.Database) error {
@@ -65,40 +77,99 @@ func listGroups(c *cobra.Command, args []string, db *rrh.Database) error {
 	if err != nil {
 		return err
 	}
-	headers, results, err := groupListResult(entry, db)
+	f, err := formatter.New(listOpts.format, !listOpts.header)
 	if err != nil {
 		return err
 	}
-	formatter, err := NewFormatter(listOpts.format, !listOpts.header)
+	groups, err := sortGroups(db, listOpts.sortKey, listOpts.reverse)
 	if err != nil {
 		return err
 	}
-	return formatter.Format(c.OutOrStdout(), headers, results)
+	return f.Format(c.OutOrStdout(), groupListResult(entry, db, groups, isDecorated(listOpts.format), isHumanReadable(listOpts.format)))
+}
+
+/*
+sortGroups returns the groups in the database sorted by the given key, without changing the order in the database.
+*/
+func sortGroups(db *rrh.Database, key string, reverse bool) ([]*rrh.Group, error) {
+	groups := append([]*rrh.Group{}, db.Groups...)
+	if key == "" {
+		return groups, nil
+	}
+	sorter, err := rrh.NewRepositorySorter(db, key, reverse)
+	if err != nil {
+		return nil, err
+	}
+	sorter.SortGroups(db, groups)
+	return groups, nil
+}
+
+/*
+isDecorated returns true if the group names are decorated in the given format.
+Only the formats for the terminals are decorated.
+*/
+func isDecorated(format string) bool {
+	name, _ := formatter.Parse(format)
+	return name == "default" || name == "table"
+}
+
+/*
+isHumanReadable returns true if the given format is for the humans (default, table, csv, and markdown).
+The formats for the machines print the repository count as the number.
+*/
+func isHumanReadable(format string) bool {
+	name, _ := formatter.Parse(format)
+	return name == "default" || name == "table" || name == "csv" || name == "markdown"
 }
 
-func groupListResult(ge Entries, db *rrh.Database) (headers []string, values [][]string, err error) {
-	results := [][]string{}
-	decorator := db.Config.Decorator
-	for _, group := range db.Groups {
-		resultItems := []string{}
+func groupListResult(ge Entries, db *rrh.Database, groups []*rrh.Group, decorated, humanized bool) *formatter.Table {
+	table := formatter.NewTable(ge.Columns()...)
+	for _, group := range groups {
+		resultItems := []interface{}{}
 		if ge.IsName() {
-			resultItems = append(resultItems, decorator.GroupName(group.Name))
+			resultItems = append(resultItems, decoratedGroupName(db, group, decorated))
 		}
 		if ge.IsDesc() {
 			resultItems = append(resultItems, group.Description)
 		}
 		if ge.IsAbbrev() {
-			resultItems = append(resultItems, strconv.FormatBool(group.OmitList))
+			resultItems = append(resultItems, group.OmitList)
 		}
 		if ge.IsRepo() {
-			list := db.FindRelationsOfGroup(group.Name)
-			resultItems = append(resultItems, fmt.Sprintf("%v", list))
+			resultItems = append(resultItems, db.FindRelationsOfGroup(group.Name))
 		}
 		if ge.IsCount() {
-			count := db.ContainsCount(group.Name)
-			resultItems = append(resultItems, english.Plural(count, "repository", ""))
+			resultItems = append(resultItems, countString(db.ContainsCount(group.Name), humanized))
 		}
-		results = append(results, resultItems)
+		if ge.IsParent() {
+			resultItems = append(resultItems, strings.Join(reverse(db.FindAncestorGroups(group.Name)), rrh.GroupPathSeparator))
+		}
+		if ge.IsRules() {
+			resultItems = append(resultItems, group.Rules)
+		}
+		table.Append(resultItems...)
+	}
+	return table
+}
+
+func countString(count int, humanized bool) interface{} {
+	if humanized {
+		return english.Plural(count, "repository", "")
+	}
+	return count
+}
+
+func decoratedGroupName(db *rrh.Database, group *rrh.Group, decorated bool) string {
+	if decorated {
+		return db.Config.Decorator.GroupName(group.Name)
+	}
+	return group.Name
+}
+
+func reverse(items []string) []string {
+	results := []string{}
+	for _, item := range items {
+		results = append([]string{item}, results...)
 	}
-	return ge.StringArray(), results, nil
+	return results
 }
@@ -11,9 +11,10 @@ import (
 
 func createGroupOfCommand() *cobra.Command {
 	command := &cobra.Command{
-		Use:   "of <REPOSITORY_ID>",
-		Short: "print the group name of the specified repository",
-		Args:  cobra.ExactArgs(1),
+		Use:               "of <REPOSITORY_ID>",
+		Short:             "print the group name of the specified repository",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: utils.CompleteRepository,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, func(c *cobra.Command, args []string, db *rrh.Database) error {
 				if !db.HasRepository(args[0]) {
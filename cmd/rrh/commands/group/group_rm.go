@@ -14,6 +14,7 @@ import (
 type removeOptions struct {
 	inquiry    bool
 	force      bool
+	recursive  bool
 	dryRunFlag bool
 }
 
@@ -21,8 +22,14 @@ var removeOpts = &removeOptions{}
 
 func createGroupRemoveCommand() *cobra.Command {
 	c := &cobra.Command{
-		Use:  "rm <GROUP NAMEs...>",
-		Args: cobra.MinimumNArgs(1),
+		Use:   "rm <GROUP NAMEs...>",
+		Short: "remove the given groups",
+		Long: `remove the given groups.
+The group having repositories or child groups is not removed without options.
+    --force      removes the group with its relations, and moves its child groups to its parent.
+    --recursive  removes the group and all of its descendant groups (requires --force, if the subtree has repositories).`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: utils.CompleteGroups,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, executeGroupRemove)
 		},
@@ -30,6 +37,7 @@ func createGroupRemoveCommand() *cobra.Command {
 	flags := c.Flags()
 	flags.BoolVarP(&removeOpts.inquiry, "inquiry", "i", false, "inquiry mode")
 	flags.BoolVarP(&removeOpts.force, "force", "f", false, "force remove")
+	flags.BoolVarP(&removeOpts.recursive, "recursive", "r", false, "remove the descendant groups, too")
 	flags.BoolVarP(&removeOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
 
 	return c
@@ -103,18 +111,29 @@ func convert(results []*removeResult) []common.Resulter {
 }
 
 func removeGroupsImpl(c *cobra.Command, db *rrh.Database, groupName string) error {
-	if removeOpts.force {
+	if removeOpts.recursive {
+		if err := db.DeleteGroupRecursively(groupName, removeOpts.force); err != nil {
+			return err
+		}
+		c.Printf("%s: group and its descendants removed", groupName)
+	} else if removeOpts.force {
 		db.ForceDeleteGroup(groupName)
 		c.Printf("%s: group removed", groupName)
-	} else if db.ContainsCount(groupName) == 0 {
-		db.DeleteGroup(groupName)
-		c.Printf("%s: group removed", groupName)
-	} else {
+	} else if hasStaticRelations(db, groupName) {
 		return fmt.Errorf("%s: cannot remove group. the group has relations", groupName)
+	} else if err := db.DeleteGroup(groupName); err != nil {
+		return fmt.Errorf("%s: cannot remove group. the group has child groups", groupName)
+	} else {
+		c.Printf("%s: group removed", groupName)
 	}
 	return nil
 }
 
+func hasStaticRelations(db *rrh.Database, groupName string) bool {
+	group := db.FindGroup(groupName)
+	return group != nil && !group.IsDynamic() && db.ContainsCount(groupName) != 0
+}
+
 func inquiryRemovingGroup(inquiryFlag bool, groupName string) bool {
 	if !inquiryFlag {
 		return true
@@ -1,7 +1,9 @@
 package group
 
 import (
+	"bytes"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
@@ -109,6 +111,88 @@ func TestGroupAdd(t *testing.T) {
 
 }
 
+func TestGroupAddWithRules(t *testing.T) {
+	var testcases = []struct {
+		args        []string
+		groupName   string
+		wontError   bool
+		wontMembers []string
+	}{
+		{[]string{"add", "--rule", "remote:github.com/example/*", "gh"}, "gh", false, []string{"repo2"}},
+		{[]string{"add", "-r", "id:repo*", "-r", "!remote:*", "ids"}, "ids", true, []string{}},
+		{[]string{"add", "-r", "id:repo*", "ids"}, "ids", false, []string{"repo1", "repo2"}},
+		{[]string{"add", "-r", "unknown:value", "unknown"}, "unknown", true, []string{}},
+	}
+	for _, testcase := range testcases {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			cmd := New()
+			cmd.SetArgs(testcase.args)
+			cmd.SetOut(os.Stdout)
+			err := cmd.Execute()
+			if (err != nil) != testcase.wontError {
+				t.Errorf("%v: wont error %v, but got %v", testcase.args, testcase.wontError, err)
+			}
+
+			db2, _ := rrh.Open(config)
+			if testcase.wontError {
+				if db2.HasGroup(testcase.groupName) {
+					t.Errorf("%v: group with invalid rules was created", testcase.args)
+				}
+				return
+			}
+			members := db2.FindRelationsOfGroup(testcase.groupName)
+			if strings.Join(members, ",") != strings.Join(testcase.wontMembers, ",") {
+				t.Errorf("%v: members did not match, wont: %v, got: %v", testcase.args, testcase.wontMembers, members)
+			}
+			if err := db2.Relate(testcase.groupName, "repo1"); err == nil {
+				t.Errorf("%v: dynamic group should be read-only", testcase.args)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestGroupListFormats(t *testing.T) {
+	testcases := []struct {
+		args      []string
+		wontError bool
+		output    string
+	}{
+		{[]string{"list", "-f", "json", "-e", "name,repo"}, false, `[{"name":"group1","repositories":["repo1"]},{"name":"group2","repositories":[]},{"name":"group3","repositories":["repo2"]}]`},
+		{[]string{"list", "-f", "ndjson", "-e", "name,abbrev"}, false, `{"name":"group1","abbrev":false}+{"name":"group2","abbrev":false}+{"name":"group3","abbrev":true}`},
+		{[]string{"list", "-f", "template={{.Group}}={{.Count}}"}, false, "group1=1+group2=0+group3=1"},
+		{[]string{"list", "-f", "yaml", "-e", "name,count"}, false, "- name: \"group1\"+  repository count: 1+- name: \"group2\"+  repository count: 0+- name: \"group3\"+  repository count: 1"},
+		{[]string{"list", "-f", "csv", "-H"}, false, "group1,1 repository+group2,0 repositories+group3,1 repository"},
+		{[]string{"list", "-f", "markdown", "-e", "name"}, false, "| group |+| --- |+| group1 |+| group2 |+| group3 |"},
+		{[]string{"list", "-f", "csv", "-H", "-e", "group,id", "--sort", "group", "--reverse"}, false, "group3,repo2+group2,+group1,repo1"},
+		{[]string{"list", "-f", "csv", "-H", "-e", "group", "--sort", "id", "-r"}, false, "group3+group1+group2"},
+		{[]string{"list", "-f", "csv", "-H", "-e", "group", "--sort", "path"}, false, "group1+group3+group2"},
+		{[]string{"list", "--sort", "unknown"}, true, ""},
+		{[]string{"list", "-f", "unknown"}, true, ""},
+	}
+	for _, tc := range testcases {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(tc.args)
+			cmd.SetOut(buffer)
+			cmd.SetErr(buffer)
+			err := cmd.Execute()
+			if err == nil && tc.wontError || err != nil && !tc.wontError {
+				t.Errorf("%v: wont error: %v, but got error: %v", tc.args, tc.wontError, err)
+			}
+			if tc.wontError {
+				return
+			}
+			output := rrh.ReplaceNewline(strings.TrimSpace(buffer.String()), "+")
+			if output != tc.output {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.output, output)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
 func ExampleGroupListCommand_Run() {
 	dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
 		cmd := New()
@@ -118,9 +202,9 @@ func ExampleGroupListCommand_Run() {
 	})
 	defer os.Remove(dbFile)
 	// Output:
-	// group1,desc1,[repo1],1 repository
-	// group2,desc2,[],0 repositories
-	// group3,desc3,[repo2],1 repository
+	// group1,desc1,repo1,1 repository
+	// group2,desc2,,0 repositories
+	// group3,desc3,repo2,1 repository
 }
 
 func ExampleGroupOfCommand_Run() {
@@ -149,3 +233,103 @@ func ExampleGroupInfoCommand_Run() {
 	// group2: desc2 (0 repositories, abbrev: false)
 	// Error: groupN: group not found
 }
+
+func TestGroupAuto(t *testing.T) {
+	testcases := []struct {
+		args       []string
+		rules      string
+		wontError  bool
+		wontOutput string
+		wontExists bool
+	}{
+		{[]string{"auto"}, "owner:example=example,id:repo1=group1", false, "repo2: example\n", true},
+		{[]string{"auto", "--dry-run"}, "owner:example=example", false, "repo2: example (dry-run mode)\n", false},
+		{[]string{"auto", "repo1"}, "owner:example=example", false, "", false},
+		{[]string{"auto", "unknown"}, "owner:example=example", true, "", false},
+		{[]string{"auto"}, "", true, "", false},
+	}
+	for _, testcase := range testcases {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			os.Setenv(rrh.AssignmentRules, testcase.rules)
+			defer os.Unsetenv(rrh.AssignmentRules)
+			out := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(testcase.args)
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if (err != nil) != testcase.wontError {
+				t.Errorf("%v: wont error %v, but got %v", testcase.args, testcase.wontError, err)
+			}
+			if !testcase.wontError && out.String() != testcase.wontOutput {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", testcase.args, testcase.wontOutput, out.String())
+			}
+			db2, _ := rrh.Open(config)
+			if db2.HasRelation("example", "repo2") != testcase.wontExists {
+				t.Errorf("%v: relation example/repo2 wont %v", testcase.args, testcase.wontExists)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestGroupAutoBy(t *testing.T) {
+	testcases := []struct {
+		args       []string
+		wontError  bool
+		wontOutput string
+	}{
+		{[]string{"auto", "--by", "host"}, false, "repo2: github.com\nrepo3: gitlab.example.com\n"},
+		{[]string{"auto", "--by", "owner"}, false, "repo2: example\nrepo3: team\n"},
+		{[]string{"auto", "--by", "host/owner", "repo3"}, false, "repo3: gitlab.example.com:team\n"},
+		{[]string{"auto", "--by", "parent-dir", "--dry-run"}, false, "repo3: src (dry-run mode)\n"},
+		{[]string{"auto", "--by", "unknown"}, true, ""},
+	}
+	for _, testcase := range testcases {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("repo3", "/home/user/src/repo3", "", []*rrh.Remote{
+				{Name: "upstream", URL: "https://github.com/upstream/repo3.git"},
+				{Name: "origin", URL: "ssh://git@gitlab.example.com:2222/team/repo3.git"}})
+			db.StoreAndClose()
+			out := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(testcase.args)
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if (err != nil) != testcase.wontError {
+				t.Errorf("%v: wont error %v, but got %v", testcase.args, testcase.wontError, err)
+			}
+			if !testcase.wontError && out.String() != testcase.wontOutput {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", testcase.args, testcase.wontOutput, out.String())
+			}
+			if testcase.wontError || strings.Contains(testcase.wontOutput, "dry-run") {
+				return
+			}
+			db2, _ := rrh.Open(config)
+			for _, line := range strings.Split(strings.TrimSpace(testcase.wontOutput), "\n") {
+				items := strings.SplitN(line, ": ", 2)
+				if !db2.HasRelation(items[1], items[0]) {
+					t.Errorf("%v: relation %s/%s not found", testcase.args, items[1], items[0])
+				}
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestGroupAutoCreatesGroups(t *testing.T) {
+	dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		config.Update(rrh.AutoCreateGroup, "false")
+		config.StoreConfig()
+		cmd := New()
+		cmd.SetArgs([]string{"auto", "--by", "host/owner", "repo2"})
+		cmd.SetOut(bytes.NewBuffer([]byte{}))
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("group auto failed: %s", err.Error())
+		}
+		db2, _ := rrh.Open(config)
+		if !db2.HasGroup("github.com:example") || !db2.HasRelation("github.com:example", "repo2") {
+			t.Errorf("derived group should be created regardless of %s", rrh.AutoCreateGroup)
+		}
+	})
+	defer os.Remove(dbFile)
+}
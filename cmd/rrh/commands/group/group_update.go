@@ -3,6 +3,7 @@ package group
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
@@ -13,6 +14,8 @@ type updateOptions struct {
 	name       string
 	desc       string
 	abbrev     string
+	parent     string
+	rules      []string
 	dryRunFlag bool
 }
 
@@ -20,9 +23,10 @@ var updateOpts = &updateOptions{}
 
 func createGroupUpdateCommand() *cobra.Command {
 	command := &cobra.Command{
-		Use:   "update <GROUP>",
-		Short: "update the information of the specified group",
-		Args:  cobra.ExactArgs(1),
+		Use:               "update <GROUP>",
+		Short:             "update the information of the specified group",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: utils.CompleteGroup,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, updateGroup)
 		},
@@ -31,12 +35,19 @@ func createGroupUpdateCommand() *cobra.Command {
 	flags.StringVarP(&updateOpts.name, "name", "", "", "specify the new group name")
 	flags.StringVarP(&updateOpts.desc, "note", "", "", "specify the new note of the group")
 	flags.StringVarP(&updateOpts.abbrev, "abbrev", "", "false", "specify the new abbrev flag of the group, the given string must be true or false")
+	flags.StringVarP(&updateOpts.parent, "parent", "", "", "specify the new parent group. the empty string makes the group a root group")
+	flags.StringArrayVarP(&updateOpts.rules, "rule", "", []string{}, "specify the new rules of the dynamic group (FIELD:PATTERN). --rule '' makes the group static")
 	flags.BoolVarP(&updateOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	command.RegisterFlagCompletionFunc("parent", utils.CompleteGroups)
 	return command
 }
 
 func findGroup(origName string, db *rrh.Database) (*rrh.Group, error) {
-	group := *db.FindGroup(origName)
+	found := db.FindGroup(origName)
+	if found == nil {
+		return nil, fmt.Errorf("%s: group not found", origName)
+	}
+	group := *found
 	if updateOpts.name != "" {
 		group.Name = updateOpts.name
 	}
@@ -61,6 +72,16 @@ func updateGroup(c *cobra.Command, args []string, db *rrh.Database) error {
 	if !db.UpdateGroup(args[0], group) {
 		return fmt.Errorf("%s: update failed", args[0])
 	}
+	if c.Flags().Changed("parent") {
+		if err := db.SetParentGroup(group.Name, updateOpts.parent); err != nil {
+			return err
+		}
+	}
+	if c.Flags().Changed("rule") {
+		if err := db.SetGroupRules(group.Name, updateOpts.rules); err != nil {
+			return err
+		}
+	}
 	printResult(c, args[0], group)
 	if updateOpts.dryRunFlag {
 		c.Println("(dry-run mode)")
@@ -71,5 +92,5 @@ func updateGroup(c *cobra.Command, args []string, db *rrh.Database) error {
 }
 
 func printResult(c *cobra.Command, origName string, group *rrh.Group) {
-	c.Printf("update(%s) = %s (Note: %s, Abbrev: %v)\n", origName, group.Name, group.Description, group.OmitList)
+	c.Printf("update(%s) = %s (Note: %s, Abbrev: %v, Parent: %s, Rules: %s)\n", origName, group.Name, group.Description, group.OmitList, group.Parent, strings.Join(group.Rules, ", "))
 }
@@ -0,0 +1,129 @@
+package here
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+type hereOptions struct {
+	format string
+}
+
+var hereOpts = &hereOptions{}
+
+var availableFormats = []string{"default", "id", "prompt", "json"}
+
+func New() *cobra.Command {
+	hereCommand := &cobra.Command{
+		Use:   "here [PATH]",
+		Short: "print the registered repository containing the current directory",
+		Long: `print the registered repository containing the current directory (or the given path).
+The directories are walked up to the root of the git working tree,
+and the repository of the root is searched in the database.
+The available formats are:
+    default: the id, the groups, the description, and the path of the repository.
+    id:      the id of the repository.
+    prompt:  "[GROUP/ID]" form for the shell prompts (e.g., PS1='$(rrh here -f prompt) $ ').
+             prints nothing without errors if the directory is not in the registered repository.
+    json:    the JSON object of the id, the groups, the description, and the path.
+The database is not loaded if the directory is not in the git working tree, for the shell prompts.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(c *cobra.Command, args []string) error {
+			return utils.ValidateValue(hereOpts.format, availableFormats)
+		},
+		RunE: perform,
+	}
+	flags := hereCommand.Flags()
+	flags.StringVarP(&hereOpts.format, "format", "f", "default", "specify the output format. availables: default, id, prompt, and json")
+	hereCommand.RegisterFlagCompletionFunc("format", utils.CompleteValues(availableFormats...))
+
+	return hereCommand
+}
+
+/*
+Here represents the repository containing the directory.
+*/
+type Here struct {
+	ID          string   `json:"id"`
+	Groups      []string `json:"groups"`
+	Description string   `json:"description"`
+	Path        string   `json:"path"`
+}
+
+/*
+FindHere returns the registered repository of the given git root.
+The registered repository containing the git root (e.g., the parent of the nested repository) is not the repository of it.
+*/
+func FindHere(db *rrh.Database, gitRoot string) (*Here, error) {
+	var repo = db.FindRepositoryByPath(gitRoot)
+	if repo == nil || filepath.Clean(repo.Path) != gitRoot {
+		return nil, fmt.Errorf("%s: not registered repository", gitRoot)
+	}
+	return &Here{ID: repo.ID, Groups: db.FindRelationsOfRepository(repo.ID), Description: repo.Description, Path: repo.Path}, nil
+}
+
+func findHere(args []string) (*Here, error) {
+	var path = "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+	var gitRoot, err = rrh.FindGitRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := rrh.Open(rrh.OpenConfig())
+	if err != nil {
+		return nil, err
+	}
+	return FindHere(db, gitRoot)
+}
+
+func perform(c *cobra.Command, args []string) error {
+	c.SilenceUsage = true
+	var here, err = findHere(args)
+	if err != nil {
+		if strings.ToLower(hereOpts.format) == "prompt" {
+			return nil
+		}
+		return err
+	}
+	return printHere(c, here, strings.ToLower(hereOpts.format))
+}
+
+func printHere(c *cobra.Command, here *Here, format string) error {
+	switch format {
+	case "id":
+		c.Println(here.ID)
+	case "prompt":
+		c.Println(formatPrompt(here))
+	case "json":
+		var data, err = json.Marshal(here)
+		if err != nil {
+			return err
+		}
+		c.Println(string(data))
+	default:
+		c.Printf("ID:          %s\n", here.ID)
+		c.Printf("Groups:      %s\n", strings.Join(here.Groups, ", "))
+		c.Printf("Description: %s\n", here.Description)
+		c.Printf("Path:        %s\n", here.Path)
+	}
+	return nil
+}
+
+/*
+formatPrompt returns "[GROUP/ID]" form of the given repository.
+If the repository belongs to two or more groups, the first group is used.
+*/
+func formatPrompt(here *Here) string {
+	if len(here.Groups) == 0 {
+		return fmt.Sprintf("[%s]", here.ID)
+	}
+	return fmt.Sprintf("[%s/%s]", here.Groups[0], here.ID)
+}
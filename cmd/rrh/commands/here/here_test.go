@@ -0,0 +1,79 @@
+package here
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+)
+
+func TestHere(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-here")
+	defer os.RemoveAll(dir)
+	var root = filepath.Join(dir, "api")
+	if _, err := git.PlainInit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(root, "sub"), 0755)
+	var nested = filepath.Join(root, "vendor", "lib")
+	if _, err := git.PlainInit(nested, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var testcases = []struct {
+		args     []string
+		hasError bool
+		want     string
+	}{
+		{[]string{filepath.Join(root, "sub")}, false, "ID:          api\nGroups:      payments\nDescription: payment api\nPath:        " + root + "\n"},
+		{[]string{"-f", "id", root}, false, "api\n"},
+		{[]string{"--format", "prompt", filepath.Join(root, "sub")}, false, "[payments/api]\n"},
+		{[]string{"-f", "json", root}, false, `{"id":"api","groups":["payments"],"description":"payment api","path":"` + root + `"}` + "\n"},
+		{[]string{"-f", "prompt", dir}, false, ""},
+		{[]string{dir}, true, ""},
+		{[]string{nested}, true, ""},
+		{[]string{"-f", "prompt", nested}, false, ""},
+		{[]string{"-f", "unknown", root}, true, ""},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("api", root, "payment api", []*rrh.Remote{})
+			db.CreateGroup("payments", "", false)
+			db.Relate("payments", "api")
+			db.StoreAndClose()
+
+			var buffer = bytes.NewBuffer([]byte{})
+			var cmd = New()
+			cmd.SetArgs(tc.args)
+			cmd.SetOut(buffer)
+			cmd.SetErr(ioutil.Discard)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			if !tc.hasError && buffer.String() != tc.want {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.want, buffer.String())
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestFormatPrompt(t *testing.T) {
+	var testcases = []struct {
+		here *Here
+		want string
+	}{
+		{&Here{ID: "repo1", Groups: []string{}}, "[repo1]"},
+		{&Here{ID: "repo1", Groups: []string{"group1", "group2"}}, "[group1/repo1]"},
+	}
+	for _, tc := range testcases {
+		if got := formatPrompt(tc.here); got != tc.want {
+			t.Errorf("formatPrompt(%v) did not match, wont: %s, got: %s", tc.here, tc.want, got)
+		}
+	}
+}
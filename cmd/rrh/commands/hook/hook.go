@@ -0,0 +1,119 @@
+package hook
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/add"
+)
+
+type chpwdOptions struct {
+	from string
+}
+
+var chpwdOpts = &chpwdOptions{}
+
+func New() *cobra.Command {
+	hookCommand := &cobra.Command{
+		Use:   "hook <subcommand>",
+		Short: "the hooks called from the shell (see \"rrh init --hook\")",
+	}
+	hookCommand.AddCommand(newChpwdCommand())
+	return hookCommand
+}
+
+func newChpwdCommand() *cobra.Command {
+	chpwdCommand := &cobra.Command{
+		Use:   "chpwd [DIR]",
+		Short: "handle the change of the current directory",
+		Long: `handle the change of the current directory (or the given directory).
+If the directory is in the registered repository, the last accessed time of the repository is updated.
+If the directory is in the unregistered git repository, the repository is offered to register (RRH_HOOK_MODE=offer),
+or the repository is registered to the default group (RRH_HOOK_MODE=auto).
+The repositories matched to the patterns in RRH_HOOK_IGNORES are ignored.
+This command does nothing if the directory moves in the same git repository of --from option.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: performChpwd,
+	}
+	flags := chpwdCommand.Flags()
+	flags.StringVarP(&chpwdOpts.from, "from", "", "", "specify the previous directory")
+	return chpwdCommand
+}
+
+/*
+findTargetRoot returns the root of the git working tree of the given directory.
+The empty string is returned if the directory is not in the git working tree, or in the same working tree of the previous directory.
+*/
+func findTargetRoot(dir, from string) string {
+	var root, err = rrh.FindGitRoot(dir)
+	if err != nil {
+		return ""
+	}
+	if from != "" {
+		if fromRoot, err := rrh.FindGitRoot(from); err == nil && fromRoot == root {
+			return ""
+		}
+	}
+	return root
+}
+
+func performChpwd(c *cobra.Command, args []string) error {
+	c.SilenceUsage = true
+	var dir = "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	var root = findTargetRoot(dir, chpwdOpts.from)
+	if root == "" {
+		return nil
+	}
+	var config = rrh.OpenConfig()
+	if rrh.IsHookIgnored(config, root) {
+		return nil
+	}
+	var db, err = rrh.Open(config)
+	if err != nil {
+		return err
+	}
+	return Chpwd(c, db, root)
+}
+
+/*
+Chpwd updates the last accessed time of the repository of the given git root,
+or handles the unregistered repository by RRH_HOOK_MODE.
+*/
+func Chpwd(c *cobra.Command, db *rrh.Database, root string) error {
+	if repo := db.FindRepositoryByPath(root); repo != nil && filepath.Clean(repo.Path) == root {
+		if err := db.Touch(repo.ID); err != nil {
+			return err
+		}
+		return db.StoreAndClose()
+	}
+	if rrh.HookModeOf(db.Config) != rrh.HookAuto {
+		c.PrintErrf("rrh: %s is not registered, run \"rrh add %s\" to register it (or add it to %s for ignoring)\n", root, root, rrh.HookIgnores)
+		return nil
+	}
+	var groups, err = register(db, root)
+	if err != nil {
+		return err
+	}
+	c.PrintErrf("rrh: %s is registered as %s (groups: %s)\n", root, filepath.Base(root), strings.Join(groups, ", "))
+	return db.StoreAndClose()
+}
+
+/*
+register registers the repository of the given path in the same way of add command (see add.Register).
+The default group (RRH_DEFAULT_GROUP_NAME) is related if no assignment rules (RRH_ASSIGNMENT_RULES) match.
+*/
+func register(db *rrh.Database, root string) ([]string, error) {
+	var id = filepath.Base(root)
+	var registered = db.HasRepository(id)
+	var groups, err = add.Register(db, id, root, []string{db.Config.GetValue(rrh.DefaultGroupName)}, true)
+	if err != nil && registered {
+		return nil, fmt.Errorf("%s, run \"rrh add --repository-id <ID> %s\" to register it", err.Error(), root)
+	}
+	return groups, err
+}
@@ -0,0 +1,106 @@
+package hook
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+)
+
+func initGitRepository(t *testing.T, path string) {
+	if _, err := git.PlainInit(path, false); err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(path, "sub"), 0755)
+}
+
+func TestChpwd(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-hook")
+	defer os.RemoveAll(dir)
+	initGitRepository(t, filepath.Join(dir, "registered"))
+	initGitRepository(t, filepath.Join(dir, "newrepo"))
+	initGitRepository(t, filepath.Join(dir, "ignored"))
+
+	var testcases = []struct {
+		args        []string
+		mode        string
+		wontTouched bool
+		wontAdded   []string
+		wontMessage string
+	}{
+		{[]string{filepath.Join(dir, "registered", "sub")}, "offer", true, []string{}, ""},
+		{[]string{"--from", filepath.Join(dir, "registered"), filepath.Join(dir, "registered", "sub")}, "offer", false, []string{}, ""},
+		{[]string{dir}, "offer", false, []string{}, ""},
+		{[]string{filepath.Join(dir, "newrepo")}, "offer", false, []string{}, "is not registered"},
+		{[]string{filepath.Join(dir, "newrepo", "sub")}, "auto", false, []string{"no-group"}, "is registered as newrepo"},
+		{[]string{filepath.Join(dir, "ignored")}, "auto", false, []string{}, ""},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("registered", filepath.Join(dir, "registered"), "", []*rrh.Remote{})
+			db.StoreAndClose()
+			os.Setenv(rrh.HookMode, tc.mode)
+			os.Setenv(rrh.HookIgnores, "ignored")
+			defer os.Unsetenv(rrh.HookMode)
+			defer os.Unsetenv(rrh.HookIgnores)
+
+			var buffer = bytes.NewBuffer([]byte{})
+			var cmd = New()
+			cmd.SetArgs(append([]string{"chpwd"}, tc.args...))
+			cmd.SetErr(buffer)
+			if err := cmd.Execute(); err != nil {
+				t.Errorf("%v: unexpected error: %s", tc.args, err.Error())
+			}
+			if tc.wontMessage == "" && buffer.String() != "" || !strings.Contains(buffer.String(), tc.wontMessage) {
+				t.Errorf("%v: message did not match, wont: %s, got: %s", tc.args, tc.wontMessage, buffer.String())
+			}
+			var db2, _ = rrh.Open(config)
+			if got := db2.FindRepository("registered").LastAccessed != nil; got != tc.wontTouched {
+				t.Errorf("%v: touched flag did not match, wont: %v, got: %v", tc.args, tc.wontTouched, got)
+			}
+			var groups = []string{}
+			if db2.HasRepository("newrepo") {
+				groups = db2.FindRelationsOfRepository("newrepo")
+			}
+			if strings.Join(groups, ",") != strings.Join(tc.wontAdded, ",") {
+				t.Errorf("%v: groups of the new repository did not match, wont: %v, got: %v", tc.args, tc.wontAdded, groups)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestRegisterSharesAddRegistration(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-hook")
+	defer os.RemoveAll(dir)
+	var root = filepath.Join(dir, "repo2")
+	var repo, _ = git.PlainInit(root, false)
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:example/repo2.git"}})
+	initGitRepository(t, filepath.Join(dir, "newrepo"))
+
+	var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+		cfg.Update(rrh.Hostname, "workstation")
+		if _, err := register(db, root); err != nil {
+			t.Errorf("repository registered on the other host should be registered, got: %s", err.Error())
+		}
+		if hp := db.FindRepository("repo2").FindHostPath("workstation"); hp == nil || hp.Path != root {
+			t.Errorf("path of this host should be added, got: %v", hp)
+		}
+
+		cfg.Update(rrh.AutoCreateGroup, "false")
+		cfg.Update(rrh.DefaultGroupName, "not-exist")
+		if _, err := register(db, filepath.Join(dir, "newrepo")); err == nil {
+			t.Errorf("default group should not be created without %s", rrh.AutoCreateGroup)
+		}
+		if db.HasGroup("not-exist") {
+			t.Errorf("default group should not be created without %s", rrh.AutoCreateGroup)
+		}
+	})
+	defer os.Remove(dbFile)
+}
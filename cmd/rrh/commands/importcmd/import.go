@@ -19,6 +19,7 @@ type importOptions struct {
 	autoClone bool
 	overwrite bool
 	dryRun    bool
+	mapRoots  []string
 }
 
 var importOpts = &importOptions{}
@@ -27,7 +28,10 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "import",
 		Short: "import the given database",
-		Args:  cobra.ExactArgs(1),
+		Long: `import the given database.
+The repositories stored relative to the workspace roots are resolved by RRH_WORKSPACE_ROOTS of this machine.
+--map-root FROM=TO remaps the root FROM in the given database to the local root TO (the root name or the directory path).`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, perform)
 		},
@@ -36,6 +40,7 @@ func New() *cobra.Command {
 	flags.BoolVarP(&importOpts.autoClone, "auto-clone", "", false, "clone the repository, if paths do not exist")
 	flags.BoolVarP(&importOpts.overwrite, "overwrite", "", false, "replace the local RRH database to the given database")
 	flags.BoolVarP(&importOpts.dryRun, "dry-run", "D", false, "dry-run mode")
+	flags.StringArrayVarP(&importOpts.mapRoots, "map-root", "m", []string{}, "remap the workspace root in the given database (FROM=TO)")
 	return cmd
 }
 
@@ -47,7 +52,43 @@ func perform(c *cobra.Command, args []string, db *rrh.Database) error {
 	if err != nil {
 		return err
 	}
-	return copyDB(db2, db)
+	roots, err := buildRoots(importOpts.mapRoots, db.Config.WorkspaceRoots())
+	if err != nil {
+		return err
+	}
+	return copyDB(db2, db, roots)
+}
+
+/*
+buildRoots returns the workspace roots for resolving the imported repositories.
+The remapped roots take precedence over the local roots.
+*/
+func buildRoots(mappings []string, localRoots []*rrh.WorkspaceRoot) ([]*rrh.WorkspaceRoot, error) {
+	var roots = []*rrh.WorkspaceRoot{}
+	for _, mapping := range mappings {
+		var root, err = parseMapping(mapping, localRoots)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return append(roots, localRoots...), nil
+}
+
+func parseMapping(mapping string, localRoots []*rrh.WorkspaceRoot) (*rrh.WorkspaceRoot, error) {
+	var items = strings.SplitN(mapping, "=", 2)
+	if len(items) == 2 {
+		for _, local := range localRoots {
+			if local.Name == strings.TrimSpace(items[1]) {
+				return &rrh.WorkspaceRoot{Name: strings.TrimSpace(items[0]), Path: local.Path}, nil
+			}
+		}
+	}
+	var root, err = rrh.ParseWorkspaceRoot(mapping)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid root mapping, the mapping must be FROM=TO form", mapping)
+	}
+	return root, nil
 }
 
 func eraseDatabase(db *rrh.Database) {
@@ -70,10 +111,10 @@ func readNewDB(path string, config *rrh.Config) (*rrh.Database, error) {
 	return &db, nil
 }
 
-func copyDB(from *rrh.Database, to *rrh.Database) common.ErrorList {
+func copyDB(from *rrh.Database, to *rrh.Database, roots []*rrh.WorkspaceRoot) common.ErrorList {
 	var errs = []error{}
 	var errs1 = copyGroups(from, to)
-	var errs2 = copyRepositories(from, to)
+	var errs2 = copyRepositories(from, to, roots)
 	var errs3 = copyRelations(from, to)
 	errs = append(errs, errs1...)
 	errs = append(errs, errs2...)
@@ -88,9 +129,12 @@ func copyGroup(group *rrh.Group, to *rrh.Database) common.ErrorList {
 			list = append(list, fmt.Errorf("%s: update failed", group.Name))
 		}
 	} else {
-		var _, err = to.CreateGroup(group.Name, group.Description, group.OmitList)
+		var created, err = to.CreateGroup(group.Name, group.Description, group.OmitList)
 		if err != nil {
 			list = append(list, err)
+		} else {
+			created.Parent = group.Parent
+			created.Rules = append([]string{}, group.Rules...)
 		}
 	}
 	return list
@@ -140,32 +184,88 @@ func cloneIfNeeded(repository *rrh.Repository) error {
 	return nil
 }
 
-func copyRepository(repository *rrh.Repository, to *rrh.Database) common.ErrorList {
+/*
+importedPath returns the path of the given repository on this host.
+The path of this host in the host paths takes precedence over the path of the repository.
+*/
+func importedPath(repository *rrh.Repository, host string, roots []*rrh.WorkspaceRoot) (string, error) {
+	if hp := repository.FindHostPath(host); hp != nil {
+		var entry = &rrh.Repository{ID: repository.ID, Root: hp.Root, Path: hp.Path}
+		if err := rrh.ResolveRepositoryPath(entry, roots); err != nil {
+			return "", err
+		}
+		rrh.ResolveRepositoryPath(repository, roots)
+		return entry.Path, nil
+	}
+	if err := rrh.ResolveRepositoryPath(repository, roots); err != nil {
+		return "", err
+	}
+	return repository.Path, nil
+}
+
+func copyRepository(repository *rrh.Repository, to *rrh.Database, roots []*rrh.WorkspaceRoot) common.ErrorList {
 	if to.HasRepository(repository.ID) {
 		return []error{}
 	}
-	var _, err = os.Stat(repository.Path)
+	var host = rrh.CurrentHostname(to.Config)
+	var path, err = importedPath(repository, host, roots)
 	if err != nil {
-		var err1 = cloneIfNeeded(repository)
-		if err1 != nil {
+		return []error{err}
+	}
+	if _, err := os.Stat(path); err != nil {
+		var target = *repository
+		target.Path = path
+		if err1 := cloneIfNeeded(&target); err1 != nil {
 			return []error{err1}
 		}
 	}
-	return copyRepositoryImpl(repository, to)
+	return copyRepositoryImpl(repository, path, host, to)
 }
 
-func copyRepositoryImpl(repository *rrh.Repository, to *rrh.Database) common.ErrorList {
-	if err := rrh.IsExistAndGitRepository(repository.Path, repository.ID); err != nil {
+/*
+copyRepositoryImpl registers the given repository of the given path with its fields, such as the tags, the host paths, and the times.
+*/
+func copyRepositoryImpl(repository *rrh.Repository, path string, host string, to *rrh.Database) common.ErrorList {
+	if err := rrh.IsExistAndGitRepository(path, repository.ID); err != nil {
+		return []error{err}
+	}
+	var repo, err = to.CreateRepository(repository.ID, path, repository.Description, repository.Remotes)
+	if err != nil {
 		return []error{err}
 	}
-	to.CreateRepository(repository.ID, repository.Path, repository.Description, repository.Remotes)
+	repo.Root, repo.Path = repository.Root, repository.Path
+	repo.Tags = copyTags(repository.Tags)
+	repo.HostPaths = copyHostPaths(repository.HostPaths)
+	if repository.FindHostPath(host) != nil {
+		to.PutHostPath(repository.ID, host, path)
+	}
+	repo.AddedAt, repo.UpdatedAt, repo.LastAccessed = repository.AddedAt, repository.UpdatedAt, repository.LastAccessed
+	repo.AccessCount = repository.AccessCount
 	return []error{}
 }
 
-func copyRepositories(from *rrh.Database, to *rrh.Database) []error {
+func copyTags(tags []*rrh.Tag) []*rrh.Tag {
+	var results = []*rrh.Tag{}
+	for _, tag := range tags {
+		var copied = *tag
+		results = append(results, &copied)
+	}
+	return results
+}
+
+func copyHostPaths(hostPaths []*rrh.HostPath) []*rrh.HostPath {
+	var results = []*rrh.HostPath{}
+	for _, hp := range hostPaths {
+		var copied = *hp
+		results = append(results, &copied)
+	}
+	return results
+}
+
+func copyRepositories(from *rrh.Database, to *rrh.Database, roots []*rrh.WorkspaceRoot) []error {
 	var list = common.NewErrorList()
 	for _, repository := range from.Repositories {
-		var errs = copyRepository(repository, to)
+		var errs = copyRepository(repository, to, roots)
 		list = list.Append(errs)
 	}
 	return list
@@ -0,0 +1,68 @@
+package importcmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+)
+
+func TestCopyDB(t *testing.T) {
+	os.Setenv(rrh.Hostname, "this-host")
+	defer os.Unsetenv(rrh.Hostname)
+	dir, _ := ioutil.TempDir("", "rrh-import")
+	defer os.RemoveAll(dir)
+	var path, hostPath = filepath.Join(dir, "imported"), filepath.Join(dir, "host")
+	git.PlainInit(path, false)
+	git.PlainInit(hostPath, false)
+
+	var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, from *rrh.Database) {
+		var added, accessed = rrh.Unix(1000000000, 0), rrh.Unix(1000086400, 0)
+		repo, _ := from.CreateRepository("imported", path, "desc", []*rrh.Remote{})
+		repo.Tags = []*rrh.Tag{{Key: "lang", Value: "go"}}
+		repo.HostPaths = []*rrh.HostPath{{Host: "other-host", Path: "/somewhere/imported"}, {Host: "this-host", Path: hostPath}}
+		repo.AddedAt, repo.UpdatedAt, repo.LastAccessed, repo.AccessCount = &added, &added, &accessed, 3
+		from.Relate("group1", "imported")
+		from.CreateGroup("parent", "parent group", false)
+		child, _ := from.CreateGroup("child", "child group", false)
+		child.Parent = "parent"
+		child.Rules = []string{"id:repo*"}
+
+		to := &rrh.Database{Repositories: []*rrh.Repository{}, Groups: []*rrh.Group{}, Relations: []*rrh.Relation{}, Config: config}
+		copyDB(from, to, config.WorkspaceRoots())
+
+		if group := to.FindGroup("child"); group == nil || group.Parent != "parent" || strings.Join(group.Rules, ",") != "id:repo*" {
+			t.Errorf("group was not copied with its parent and rules, got %v", group)
+		}
+		imported := to.FindRepository("imported")
+		if imported == nil {
+			t.Fatalf("repository was not imported")
+		}
+		if imported.Path != hostPath {
+			t.Errorf("path did not match, wont %s, got %s", hostPath, imported.Path)
+		}
+		if hp := imported.FindHostPath("other-host"); hp == nil || hp.Path != "/somewhere/imported" {
+			t.Errorf("host path of other-host was not copied, got %v", hp)
+		}
+		if strings.Join(imported.TagStrings(), ",") != "lang=go" {
+			t.Errorf("tags did not match, wont lang=go, got %v", imported.TagStrings())
+		}
+		if imported.AddedAt == nil || !imported.AddedAt.Time().Equal(added.Time()) {
+			t.Errorf("added at did not match, wont %v, got %v", added, imported.AddedAt)
+		}
+		if imported.LastAccessed == nil || !imported.LastAccessed.Time().Equal(accessed.Time()) {
+			t.Errorf("last accessed did not match, wont %v, got %v", accessed, imported.LastAccessed)
+		}
+		if imported.AccessCount != 3 {
+			t.Errorf("access count did not match, wont 3, got %d", imported.AccessCount)
+		}
+		if !to.HasRelation("group1", "imported") {
+			t.Errorf("relation was not copied")
+		}
+	})
+	defer os.Remove(dbFile)
+}
@@ -0,0 +1,107 @@
+package jump
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+type jumpOptions struct {
+	listFlag bool
+}
+
+var jumpOpts = &jumpOptions{}
+
+func New() *cobra.Command {
+	jumpCommand := &cobra.Command{
+		Use:   "jump [QUERIES...]",
+		Short: "print the path of the repository best matched to the given queries",
+		Long: `print the path of the repository best matched to the given queries.
+Each query is fuzzy matched to the id, the path, and the groups of the repositories,
+and the matched repositories are ranked by the frequency and the recency of the accesses (frecency).
+The accesses are recorded by "rrh touch", "rrh open", and "rrh exec".
+The repository whose id is the same as the query is always the best.`,
+		ValidArgsFunction: utils.CompleteRepositories,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	flags := jumpCommand.Flags()
+	flags.BoolVarP(&jumpOpts.listFlag, "list", "l", false, "print the scored candidates instead of the best path")
+
+	return jumpCommand
+}
+
+/*
+Candidate represents the repository matched to the queries with its frecency score.
+*/
+type Candidate struct {
+	Repository *rrh.Repository
+	Score      float64
+	exact      bool
+}
+
+func matchQuery(db *rrh.Database, repo *rrh.Repository, query string) bool {
+	if rrh.FuzzyMatch(query, repo.ID) || rrh.FuzzyMatch(query, repo.Path) {
+		return true
+	}
+	for _, group := range db.FindRelationsOfRepository(repo.ID) {
+		if rrh.FuzzyMatch(query, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchQueries(db *rrh.Database, repo *rrh.Repository, queries []string) bool {
+	for _, query := range queries {
+		if !matchQuery(db, repo, query) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Rank returns the repositories matched to all of the given queries in the order of the frecency.
+The repository whose id is the same as the query is placed at the first.
+*/
+func Rank(db *rrh.Database, queries []string, now time.Time) []*Candidate {
+	var results = []*Candidate{}
+	var query = strings.Join(queries, " ")
+	for _, repo := range db.Repositories {
+		if matchQueries(db, repo, queries) {
+			results = append(results, &Candidate{Repository: repo, Score: repo.Frecency(now), exact: repo.ID == query})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].exact != results[j].exact {
+			return results[i].exact
+		}
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Repository.ID < results[j].Repository.ID
+	})
+	return results
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	var candidates = Rank(db, args, time.Now())
+	if jumpOpts.listFlag {
+		for _, candidate := range candidates {
+			c.Printf("%.2f\t%s\t%s\n", candidate.Score, candidate.Repository.ID, candidate.Repository.Path)
+		}
+		return nil
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("%s: no repositories matched", strings.Join(args, " "))
+	}
+	c.Println(candidates[0].Repository.Path)
+	return nil
+}
@@ -0,0 +1,74 @@
+package jump
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tamada/rrh"
+)
+
+func TestRank(t *testing.T) {
+	var testcases = []struct {
+		queries []string
+		wontIDs []string
+	}{
+		{[]string{}, []string{"repo2", "rrh", "repo1"}},
+		{[]string{"rp"}, []string{"repo2", "repo1"}},
+		{[]string{"repo1"}, []string{"repo1"}},
+		{[]string{"rp", "group1"}, []string{"repo1"}},
+		{[]string{"tamada"}, []string{"rrh"}},
+		{[]string{"unknown"}, []string{}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("rrh", "/home/tamada/src/rrh", "", []*rrh.Remote{})
+			db.Touch("rrh")
+			db.Touch("repo2")
+			db.Touch("repo2")
+
+			var ids = []string{}
+			for _, candidate := range Rank(db, tc.queries, time.Now()) {
+				ids = append(ids, candidate.Repository.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.wontIDs, ",") {
+				t.Errorf("%v: ranked repositories did not match, wont: %v, got: %v", tc.queries, tc.wontIDs, ids)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestJump(t *testing.T) {
+	var testcases = []struct {
+		args     []string
+		hasError bool
+		wont     string
+	}{
+		{[]string{"rrh"}, false, "/home/tamada/src/rrh\n"},
+		{[]string{"--list", "rrh"}, false, "4.00\trrh\t/home/tamada/src/rrh\n"},
+		{[]string{"unknown"}, true, ""},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("rrh", "/home/tamada/src/rrh", "", []*rrh.Remote{})
+			db.Touch("rrh")
+			db.StoreAndClose()
+
+			var out = bytes.NewBuffer([]byte{})
+			var cmd = New()
+			cmd.SetOut(out)
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			if !tc.hasError && out.String() != tc.wont {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.wont, out.String())
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
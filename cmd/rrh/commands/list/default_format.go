@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/tamada/rrh"
@@ -15,28 +16,38 @@ type defaultFormat struct {
 }
 
 func (df *defaultFormat) formatEach(writer *bufio.Writer, r *Result, li Entries, noAbbrevFlag bool) error {
+	indent := strings.Repeat("    ", r.Depth)
+	writer.WriteString(indent)
 	if li.IsGroupName() {
 		writer.WriteString(df.deco.GroupName(r.GroupName))
 	}
 	if li.IsRepositoryCount() {
-		writer.WriteString(" (" + english.Plural(len(r.Repos), "repository", "repositories") + ")")
+		writer.WriteString(" (" + formatCount(r) + ")")
 	}
 	if !noAbbrevFlag && r.Abbrev {
 		writer.WriteString(" (abbreviate repositories)")
 	} else {
 		if li.IsNote() {
-			writer.WriteString(fmt.Sprintf("\n    Note: %s", r.Note))
+			writer.WriteString(fmt.Sprintf("\n%s    Note: %s", indent, r.Note))
 		}
-		df.printRepositoryInfo(writer, r, li)
+		df.printRepositoryInfo(writer, r, li, indent)
 	}
 	writer.WriteString("\n")
 	return nil
 }
 
-func (df *defaultFormat) printRepositoryInfo(writer *bufio.Writer, r *Result, li Entries) {
+func formatCount(r *Result) string {
+	count := english.Plural(len(r.Repos), "repository", "repositories")
+	if r.TotalCount > len(r.Repos) {
+		return fmt.Sprintf("%s, %d in subtree", count, r.TotalCount)
+	}
+	return count
+}
+
+func (df *defaultFormat) printRepositoryInfo(writer *bufio.Writer, r *Result, li Entries, indent string) {
 	width := computeWidth(r)
 	for _, repo := range r.Repos {
-		writer.WriteString("\n")
+		writer.WriteString("\n" + indent)
 		if li.IsRepositoryId() {
 			formatter := fmt.Sprintf("    %%s%%%ds", width-len(repo.Name))
 			writer.WriteString(fmt.Sprintf(formatter, df.deco.RepositoryID(repo.Name), ""))
@@ -45,11 +56,23 @@ func (df *defaultFormat) printRepositoryInfo(writer *bufio.Writer, r *Result, li
 			writer.WriteString(fmt.Sprintf("    %s", repo.Path))
 		}
 		if li.IsRepositoryDesc() {
-			writer.WriteString(fmt.Sprintf("\n        Desc: %s", repo.Desc))
+			writer.WriteString(fmt.Sprintf("\n%s        Desc: %s", indent, repo.Desc))
+		}
+		if li.IsRepositoryTags() && len(repo.Tags) > 0 {
+			writer.WriteString(fmt.Sprintf("\n%s        Tags: %s", indent, strings.Join(repo.Tags, ", ")))
+		}
+		if li.IsRepositoryAddedAt() && repo.AddedAt != "" {
+			writer.WriteString(fmt.Sprintf("\n%s        Added at: %s", indent, repo.AddedAt))
+		}
+		if li.IsRepositoryUpdatedAt() && repo.UpdatedAt != "" {
+			writer.WriteString(fmt.Sprintf("\n%s        Updated at: %s", indent, repo.UpdatedAt))
+		}
+		if li.IsRepositoryLastAccessed() && repo.LastAccessed != "" {
+			writer.WriteString(fmt.Sprintf("\n%s        Last accessed: %s", indent, repo.LastAccessed))
 		}
 		if li.IsRepositoryRemotes() {
 			for _, remote := range repo.Remotes {
-				writer.WriteString(fmt.Sprintf("\n        %s\t%s", remote.Name, remote.URL))
+				writer.WriteString(fmt.Sprintf("\n%s        %s\t%s", indent, remote.Name, remote.URL))
 			}
 		}
 	}
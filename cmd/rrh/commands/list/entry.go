@@ -5,20 +5,25 @@ import (
 	"strings"
 
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/formatter"
 )
 
 type Entries int
 
 const (
-	groupName         Entries = 1
-	note                      = 2
-	repositoryId              = 4
-	repositoryDesc            = 8
-	repositoryCount           = 16
-	repositoryPath            = 32
-	repositoryRemotes         = 64
-	summary                   = 128
-	all                       = groupName | note | repositoryId | repositoryDesc | repositoryCount | repositoryPath | repositoryRemotes | summary
+	groupName              Entries = 1
+	note                           = 2
+	repositoryId                   = 4
+	repositoryDesc                 = 8
+	repositoryCount                = 16
+	repositoryPath                 = 32
+	repositoryRemotes              = 64
+	summary                        = 128
+	repositoryTags                 = 256
+	repositoryAddedAt              = 512
+	repositoryUpdatedAt            = 1024
+	repositoryLastAccessed         = 2048
+	all                            = groupName | note | repositoryId | repositoryDesc | repositoryCount | repositoryPath | repositoryRemotes | summary | repositoryTags | repositoryAddedAt | repositoryUpdatedAt | repositoryLastAccessed
 )
 
 func (le Entries) IsGroupName() bool {
@@ -42,6 +47,19 @@ func (le Entries) IsRepositoryPath() bool {
 func (le Entries) IsRepositoryRemotes() bool {
 	return le&repositoryRemotes == repositoryRemotes
 }
+func (le Entries) IsRepositoryTags() bool {
+	return le&repositoryTags == repositoryTags
+}
+func (le Entries) IsRepositoryAddedAt() bool {
+	return le&repositoryAddedAt == repositoryAddedAt
+}
+func (le Entries) IsRepositoryUpdatedAt() bool {
+	return le&repositoryUpdatedAt == repositoryUpdatedAt
+}
+func (le Entries) IsRepositoryLastAccessed() bool {
+	return le&repositoryLastAccessed == repositoryLastAccessed
+}
+
 func (le Entries) IsSummary() bool {
 	return le&summary == summary
 }
@@ -67,6 +85,14 @@ func newListEntry(entries []string) (Entries, error) {
 			result = result | repositoryPath
 		case "remote":
 			result = result | repositoryRemotes
+		case "tags":
+			result = result | repositoryTags
+		case "added-at":
+			result = result | repositoryAddedAt
+		case "updated-at":
+			result = result | repositoryUpdatedAt
+		case "last-accessed":
+			result = result | repositoryLastAccessed
 		case "summary":
 			result = result | summary
 		case "all":
@@ -78,31 +104,47 @@ func newListEntry(entries []string) (Entries, error) {
 	return result, nil
 }
 
-func (li Entries) StringArray() []string {
-	result := []string{}
+/*
+Columns returns the columns of the table formats (formatter.Table) for the entries.
+The remote entry has two columns, the remote name and the remote url.
+*/
+func (li Entries) Columns() []*formatter.Column {
+	columns := []*formatter.Column{}
 	if li.IsGroupName() {
-		result = append(result, "group")
+		columns = append(columns, formatter.NewColumn("Group", "group", "group"))
 	}
 	if li.IsNote() {
-		result = append(result, "note")
+		columns = append(columns, formatter.NewColumn("Note", "note", "note"))
 	}
 	if li.IsRepositoryId() {
-		result = append(result, "repository id")
+		columns = append(columns, formatter.NewColumn("ID", "id", "repository id"))
 	}
 	if li.IsRepositoryDesc() {
-		result = append(result, "description")
+		columns = append(columns, formatter.NewColumn("Description", "description", "description"))
 	}
 	if li.IsRepositoryPath() {
-		result = append(result, "repository path")
+		columns = append(columns, formatter.NewColumn("Path", "path", "repository path"))
+	}
+	if li.IsRepositoryTags() {
+		columns = append(columns, formatter.NewColumn("Tags", "tags", "tags"))
+	}
+	if li.IsRepositoryAddedAt() {
+		columns = append(columns, formatter.NewColumn("AddedAt", "added-at", "added at"))
+	}
+	if li.IsRepositoryUpdatedAt() {
+		columns = append(columns, formatter.NewColumn("UpdatedAt", "updated-at", "updated at"))
+	}
+	if li.IsRepositoryLastAccessed() {
+		columns = append(columns, formatter.NewColumn("LastAccessed", "last-accessed", "last accessed"))
 	}
 	if li.IsRepositoryRemotes() {
-		result = append(result, "remote name")
-		result = append(result, "remote url")
+		columns = append(columns, formatter.NewColumn("RemoteName", "remote-name", "remote name"))
+		columns = append(columns, formatter.NewColumn("RemoteURL", "remote-url", "remote url"))
 	}
-	return result
+	return columns
 }
 
 func ValidateEntries(entries []string) error {
-	availables := []string{"group", "note", "id", "desc", "count", "path", "summary", "remote", "all"}
+	availables := []string{"group", "note", "id", "desc", "count", "path", "summary", "remote", "tags", "added-at", "updated-at", "last-accessed", "all"}
 	return utils.ValidateValues(entries, availables)
 }
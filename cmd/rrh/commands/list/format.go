@@ -1,38 +1,237 @@
 package list
 
 import (
+	"fmt"
 	"io"
-	"strings"
 
 	"github.com/tamada/rrh"
-	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/formatter"
 )
 
-type formatter interface {
+type listFormatter interface {
 	Format(w io.Writer, r []*Result, li Entries, noAbbrevFlag bool) error
 }
 
-func validateFormat(formatter string) error {
-	availables := []string{"default", "json", "csv", "table"}
-	return utils.ValidateValue(formatter, availables)
+/*
+availableFormats is the formats of list command.
+The default format prints the groups and the repositories with the indentation,
+the tree format prints them with the box-drawing characters,
+and the others print the table of the repositories by the formatter package.
+*/
+var availableFormats = append(append([]string{}, formatter.Formats...), "tree")
+
+func validateFormat(format string) error {
+	return formatter.Validate(format, availableFormats)
+}
+
+/*
+validateBadges validates the status badges are printed only in the tree format.
+*/
+func validateBadges(format string, badges bool) error {
+	if name, _ := formatter.Parse(format); badges && name != "tree" {
+		return fmt.Errorf("--badges is available only in the tree format")
+	}
+	return nil
 }
 
-func newFormatter(formatter string, headerFlag bool, config *rrh.Config) (formatter, error) {
-	if err := validateFormat(formatter); err != nil {
+func newFormatter(format string, headerFlag bool, badges bool, config *rrh.Config) (listFormatter, error) {
+	if err := validateFormat(format); err != nil {
 		return nil, err
 	}
-	switch strings.ToLower(formatter) {
+	switch name, _ := formatter.Parse(format); name {
 	case "default":
 		return &defaultFormat{deco: config.Decorator}, nil
-	case "json":
-		return &jsonFormat{}, nil
-	case "csv":
-		return &csvFormat{headerFlag: headerFlag}, nil
-	case "table":
-		return &tableFormat{headerFlag: headerFlag}, nil
-	default:
-		panic("never reach this line!")
+	case "tree":
+		return &treeFormat{deco: config.Decorator, badges: badges}, nil
+	case "json", "ndjson", "yaml":
+		return &structuredFormat{name: name}, nil
+	}
+	f, err := formatter.New(format, headerFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &tableFormat{formatter: f}, nil
+}
+
+/*
+structuredFormat prints the groups and their repositories in json, ndjson, and yaml formats.
+Each repository is an object having its remotes as the array.
+The json and yaml formats print the object of the groups and the summary (`{"groups": [...], "summary": {...}}`),
+and the ndjson format prints the object of each repository with its group, and the summary in the last line.
+*/
+type structuredFormat struct {
+	name string
+}
+
+func (sf *structuredFormat) Format(w io.Writer, r []*Result, li Entries, noAbbrevFlag bool) error {
+	if sf.name == "ndjson" {
+		return sf.formatLines(w, r, li, noAbbrevFlag)
+	}
+	var groups = []formatter.Object{}
+	for _, result := range r {
+		groups = append(groups, groupObject(result, li, noAbbrevFlag))
+	}
+	var root = formatter.Object{}.Put("groups", groups)
+	if li.IsSummary() {
+		root = root.Put("summary", summaryObject(r))
+	}
+	if sf.name == "yaml" {
+		return formatter.WriteYAML(w, root)
+	}
+	return formatter.WriteJSON(w, root)
+}
+
+func (sf *structuredFormat) formatLines(w io.Writer, r []*Result, li Entries, noAbbrevFlag bool) error {
+	for _, result := range r {
+		if !noAbbrevFlag && result.Abbrev {
+			continue
+		}
+		for _, repo := range result.Repos {
+			var object = formatter.Object{}
+			if li.IsGroupName() {
+				object = object.Put("group-name", result.GroupName)
+			}
+			if err := formatter.WriteJSON(w, append(object, repositoryObject(repo, li)...)); err != nil {
+				return err
+			}
+		}
+	}
+	if li.IsSummary() {
+		return formatter.WriteJSON(w, formatter.Object{}.Put("summary", summaryObject(r)))
+	}
+	return nil
+}
+
+/*
+groupObject returns the object of the given group.
+The repositories of the abbreviated group is the string "abbreviated" instead of the array.
+*/
+func groupObject(r *Result, li Entries, noAbbrevFlag bool) formatter.Object {
+	var object = formatter.Object{}
+	if li.IsGroupName() {
+		object = object.Put("group-name", r.GroupName)
+	}
+	if li.IsRepositoryCount() {
+		object = object.Put("repository-count", len(r.Repos))
+	}
+	if li.IsNote() {
+		object = object.Put("note", r.Note)
+	}
+	if !noAbbrevFlag && r.Abbrev {
+		return object.Put("repositories", "abbreviated")
+	}
+	var repos = []formatter.Object{}
+	for _, repo := range r.Repos {
+		repos = append(repos, repositoryObject(repo, li))
+	}
+	return object.Put("repositories", repos)
+}
+
+func repositoryObject(repo *Repo, li Entries) formatter.Object {
+	var object = formatter.Object{}
+	if li.IsRepositoryId() {
+		object = object.Put("id", repo.Name)
+	}
+	if li.IsRepositoryDesc() {
+		object = object.Put("description", repo.Desc)
+	}
+	if li.IsRepositoryPath() {
+		object = object.Put("path", repo.Path)
+	}
+	if li.IsRepositoryTags() {
+		object = object.Put("tags", repo.Tags)
+	}
+	if li.IsRepositoryAddedAt() {
+		object = object.Put("added-at", repo.AddedAt)
+	}
+	if li.IsRepositoryUpdatedAt() {
+		object = object.Put("updated-at", repo.UpdatedAt)
+	}
+	if li.IsRepositoryLastAccessed() {
+		object = object.Put("last-accessed", repo.LastAccessed)
+	}
+	if li.IsRepositoryRemotes() {
+		var remotes = []formatter.Object{}
+		for _, remote := range repo.Remotes {
+			remotes = append(remotes, formatter.Object{}.Put("name", remote.Name).Put("url", remote.URL))
+		}
+		object = object.Put("remotes", remotes)
+	}
+	return object
+}
+
+/*
+summaryObject returns the summary of the results.
+The keys are the same as the previous versions for the compatibility, including the misspelled key.
+*/
+func summaryObject(r []*Result) formatter.Object {
+	groupCount, repoCount, actualRepoCount := summarize(r)
+	return formatter.Object{}.Put("group-count", groupCount).
+		Put("repository-count", repoCount).
+		Put("actural-repository-count", actualRepoCount)
+}
+
+/*
+tableFormat prints the repositories in each row, with the group.
+The repository having multiple remotes is printed in the rows of each remote.
+The abbreviated groups and the summary are ignored.
+*/
+type tableFormat struct {
+	formatter formatter.Formatter
+}
+
+func (tf *tableFormat) Format(w io.Writer, r []*Result, li Entries, noAbbrevFlag bool) error {
+	table := formatter.NewTable(li.Columns()...)
+	for _, result := range r {
+		for _, repo := range result.Repos {
+			for _, remote := range repo.Remotes {
+				table.Append(formatEachRepo(result, repo, remote, li)...)
+			}
+			if len(repo.Remotes) == 0 {
+				table.Append(formatEachRepo(result, repo, nil, li)...)
+			}
+		}
+	}
+	return tf.formatter.Format(w, table)
+}
+
+func formatEachRepo(r *Result, repo *Repo, remote *rrh.Remote, li Entries) []interface{} {
+	result := []interface{}{}
+	if li.IsGroupName() {
+		result = append(result, r.GroupName)
+	}
+	if li.IsNote() {
+		result = append(result, r.Note)
+	}
+	if li.IsRepositoryId() {
+		result = append(result, repo.Name)
+	}
+	if li.IsRepositoryDesc() {
+		result = append(result, repo.Desc)
+	}
+	if li.IsRepositoryPath() {
+		result = append(result, repo.Path)
+	}
+	if li.IsRepositoryTags() {
+		result = append(result, repo.Tags)
+	}
+	if li.IsRepositoryAddedAt() {
+		result = append(result, repo.AddedAt)
+	}
+	if li.IsRepositoryUpdatedAt() {
+		result = append(result, repo.UpdatedAt)
+	}
+	if li.IsRepositoryLastAccessed() {
+		result = append(result, repo.LastAccessed)
+	}
+	if li.IsRepositoryRemotes() {
+		if remote != nil {
+			result = append(result, remote.Name, remote.URL)
+		} else {
+			result = append(result, "", "")
+		}
 	}
+	return result
 }
 
 func summarize(r []*Result) (groupCount, repositoryCount, actualRepositoryCount int) {
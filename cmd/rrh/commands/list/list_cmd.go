@@ -2,26 +2,40 @@ package list
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/filter"
+	"github.com/tamada/rrh/selector"
 )
 
 func New() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [GROUP_SELECTORs...]",
 		Short: "list groups and repositories",
-		Args:  cobra.ArbitraryArgs,
+		Long: `list groups and repositories.
+The arguments are the group selectors, such as "work,!legacy" (in work but not in legacy),
+"go&backend" (in both go and backend), and "team-*" (the groups matching the glob pattern).`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: utils.CompleteGroups,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, Perform)
 		},
 	}
 	flags := cmd.Flags()
-	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"group", "count", "id", "path", "summary"}, "specifies the printing entries.\navailables: all, group, note, count, id, desc, path, remote, and summary")
-	flags.StringVarP(&listOpts.format, "format", "f", "default", "specifies the output format. availables: csv, default, and json")
+	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"group", "count", "id", "path", "summary"}, "specifies the printing entries.\navailables: all, group, note, count, id, desc, path, remote, tags, added-at, updated-at, last-accessed, and summary")
+	flags.StringVarP(&listOpts.format, "format", "f", "default", "specifies the output format. availables: default, tree, table, csv, markdown, json, ndjson, yaml, and template=TEMPLATE (e.g., template='{{.ID}}\\t{{.Path}}')")
+	flags.BoolVarP(&listOpts.badges, "badges", "b", false, "prints the status badges (the current branch, * for dirty, or missing) of the repositories in the tree format")
 	flags.BoolVarP(&listOpts.noAbbrev, "no-abbrev", "a", false, "no abbrev mode")
 	flags.BoolVarP(&listOpts.header, "no-header", "H", false, "print without header")
+	flags.StringSliceVarP(&listOpts.tags, "tag", "t", []string{}, "specifies the tag selectors (KEY, KEY=VALUE, or !KEY) of the printing repositories")
+	flags.StringVarP(&listOpts.filter, "filter", "F", "", "specifies the filter expression of the printing repositories (e.g., group == \"work\" && !tag(\"archived\"))")
+	flags.StringVarP(&listOpts.sortKey, "sort", "s", "", "specifies the sort key of the repositories in each group, or the groups (group).\navailables: id, path, group, added-at, updated-at, last-accessed, last-commit, and size (the times are sorted in the newest first order, and the size is in the largest first order)")
+	flags.BoolVarP(&listOpts.reverse, "reverse", "r", false, "reverses the sorting order")
+	cmd.RegisterFlagCompletionFunc("format", utils.CompleteValues(availableFormats...))
+	cmd.RegisterFlagCompletionFunc("sort", utils.CompleteValues(rrh.RepositorySortKeys...))
 	return cmd
 }
 
@@ -32,7 +46,15 @@ func validateOpts(c *cobra.Command) error {
 	if err := validateFormat(listOpts.format); err != nil {
 		return err
 	}
-	return nil
+	if err := validateBadges(listOpts.format, listOpts.badges); err != nil {
+		return err
+	}
+	if listOpts.sortKey != "" {
+		if err := utils.ValidateValue(listOpts.sortKey, rrh.RepositorySortKeys); err != nil {
+			return err
+		}
+	}
+	return rrh.ValidateTagSelectors(listOpts.tags)
 }
 
 func Perform(c *cobra.Command, args []string, db *rrh.Database) error {
@@ -44,7 +66,15 @@ func Perform(c *cobra.Command, args []string, db *rrh.Database) error {
 }
 
 func performImpl(c *cobra.Command, args []string, db *rrh.Database) error {
-	results, err := FindResults(db, args)
+	f, err := filter.Compile(listOpts.filter)
+	if err != nil {
+		return err
+	}
+	results, err := FindResultsWithFilter(db, args, listOpts.tags, f)
+	if err != nil {
+		return err
+	}
+	results, err = SortResults(db, results, listOpts.sortKey, listOpts.reverse)
 	if err != nil {
 		return err
 	}
@@ -52,7 +82,7 @@ func performImpl(c *cobra.Command, args []string, db *rrh.Database) error {
 	if err != nil {
 		return err
 	}
-	formatter, err := newFormatter(listOpts.format, listOpts.header, db.Config)
+	formatter, err := newFormatter(listOpts.format, listOpts.header, listOpts.badges, db.Config)
 	if err != nil {
 		return err
 	}
@@ -70,6 +100,11 @@ type listOptions struct {
 	entries  []string
 	noAbbrev bool
 	header   bool
+	badges   bool
+	tags     []string
+	filter   string
+	sortKey  string
+	reverse  bool
 }
 
 /*
@@ -80,64 +115,238 @@ type Repo struct {
 	Path    string        `json:"path"`
 	Desc    string        `json:"desc"`
 	Remotes []*rrh.Remote `json:"remote"`
+	Tags    []string      `json:"tags"`
+
+	AddedAt      string `json:"added_at,omitempty"`
+	UpdatedAt    string `json:"updated_at,omitempty"`
+	LastAccessed string `json:"last_accessed,omitempty"`
+}
+
+func newRepo(repo *rrh.Repository, config *rrh.Config) *Repo {
+	return &Repo{Name: repo.ID, Path: repo.Path, Desc: repo.Description, Remotes: repo.Remotes, Tags: repo.TagStrings(),
+		AddedAt:      rrh.StrftimeOf(repo.AddedAt, config),
+		UpdatedAt:    rrh.StrftimeOf(repo.UpdatedAt, config),
+		LastAccessed: rrh.StrftimeOf(repo.LastAccessed, config)}
 }
 
 /*
 Result represents the result for showing.
+Depth shows the depth of the group from the listing root, and
+TotalCount shows the number of repositories in the group and its descendant groups.
 */
 type Result struct {
-	GroupName string  `json:"group"`
-	Note      string  `json:"note"`
-	Abbrev    bool    `json:"-"`
-	Repos     []*Repo `json:"repositories"`
+	GroupName  string  `json:"group"`
+	Note       string  `json:"note"`
+	Abbrev     bool    `json:"-"`
+	Repos      []*Repo `json:"repositories"`
+	Parent     string  `json:"parent"`
+	Depth      int     `json:"-"`
+	TotalCount int     `json:"-"`
+}
+
+type repoMatcher struct {
+	tags     []string
+	filter   *filter.Filter
+	selected *selector.Result
+}
+
+func (m *repoMatcher) match(db *rrh.Database, repo *rrh.Repository) (bool, error) {
+	if !repo.MatchTags(m.tags) || m.selected != nil && !m.selected.Contains(repo.ID) {
+		return false, nil
+	}
+	return m.filter.Match(db, repo)
 }
 
-func findList(db *rrh.Database, groupName string) (*Result, error) {
+func countRepositoriesInSubtree(db *rrh.Database, groupName string, matcher *repoMatcher) (int, error) {
+	count := 0
+	for _, repoID := range db.FindRelationsOfGroupRecursively(groupName) {
+		repo := db.FindRepository(repoID)
+		if repo == nil {
+			continue
+		}
+		matched, err := matcher.match(db, repo)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func findList(db *rrh.Database, groupName string, matcher *repoMatcher) (*Result, error) {
 	var repos = []*Repo{}
 	var group = db.FindGroup(groupName)
 	if group == nil {
 		return nil, fmt.Errorf("%s: group not found", groupName)
 	}
-	for _, relation := range db.Relations {
-		if relation.GroupName == groupName {
-			var repo = db.FindRepository(relation.RepositoryID)
-			if repo == nil {
-				return nil, fmt.Errorf("%s: repository not found", relation.RepositoryID)
+	for _, repoID := range db.FindRelationsOfGroup(groupName) {
+		var repo = db.FindRepository(repoID)
+		if repo == nil {
+			return nil, fmt.Errorf("%s: repository not found", repoID)
+		}
+		matched, err := matcher.match(db, repo)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			repos = append(repos, newRepo(repo, db.Config))
+		}
+	}
+	total, err := countRepositoriesInSubtree(db, groupName, matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{GroupName: group.Name, Note: group.Description, Abbrev: group.OmitList, Repos: repos,
+		Parent: group.Parent, TotalCount: total}, nil
+}
+
+/*
+SortResults sorts the results by the given key (see rrh.RepositorySortKeys), and returns the sorted results.
+The group key sorts the groups in each level of the hierarchy, and
+the other keys sort the repositories in each group.
+If the key is empty, the results are not changed.
+*/
+func SortResults(db *rrh.Database, results []*Result, key string, reverse bool) ([]*Result, error) {
+	if key == "" {
+		return results, nil
+	}
+	sorter, err := rrh.NewRepositorySorter(db, key, reverse)
+	if err != nil {
+		return nil, err
+	}
+	if sorter.IsGroupKey() {
+		return sortGroupsInHierarchy(db, results, sorter), nil
+	}
+	for _, result := range results {
+		var repos = []*rrh.Repository{}
+		var entries = map[string]*Repo{}
+		for _, repo := range result.Repos {
+			if found := db.FindRepository(repo.Name); found != nil {
+				repos = append(repos, found)
+				entries[repo.Name] = repo
 			}
-			repos = append(repos, &Repo{Name: repo.ID, Path: repo.Path, Desc: repo.Description, Remotes: repo.Remotes})
+		}
+		sorter.Sort(repos)
+		result.Repos = []*Repo{}
+		for _, repo := range repos {
+			result.Repos = append(result.Repos, entries[repo.ID])
 		}
 	}
+	return results, nil
+}
+
+/*
+sortGroupsInHierarchy sorts the results by the paths of the groups from the root group,
+therefore, the descendant groups are kept to be placed after their parent group.
+*/
+func sortGroupsInHierarchy(db *rrh.Database, results []*Result, sorter *rrh.RepositorySorter) []*Result {
+	var paths = map[string][]string{}
+	for _, result := range results {
+		var ancestors = db.FindAncestorGroups(result.GroupName)
+		var path = []string{result.GroupName}
+		for _, ancestor := range ancestors {
+			path = append([]string{ancestor}, path...)
+		}
+		paths[result.GroupName] = path
+	}
+	var sorted = append([]*Result{}, results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return lessGroupPaths(paths[sorted[i].GroupName], paths[sorted[j].GroupName], sorter)
+	})
+	return sorted
+}
+
+func lessGroupPaths(p1, p2 []string, sorter *rrh.RepositorySorter) bool {
+	for i := 0; i < len(p1) && i < len(p2); i++ {
+		if p1[i] != p2[i] {
+			return sorter.LessGroupNames(p1[i], p2[i])
+		}
+	}
+	return len(p1) < len(p2)
+}
+
+type listingGroup struct {
+	name  string
+	depth int
+}
 
-	return &Result{GroupName: group.Name, Note: group.Description, Abbrev: group.OmitList, Repos: repos}, nil
+func isRootInListing(db *rrh.Database, group *rrh.Group) bool {
+	return group.IsRoot() || !db.HasGroup(group.Parent)
 }
 
-func findAllGroupNames(db *rrh.Database) []string {
+func findAllGroupNames(db *rrh.Database) []listingGroup {
 	var names = []string{}
 	for _, group := range db.Groups {
-		names = append(names, group.Name)
+		if isRootInListing(db, group) {
+			names = append(names, group.Name)
+		}
+	}
+	return expandGroupNames(db, names)
+}
+
+func expandGroupNames(db *rrh.Database, names []string) []listingGroup {
+	var results = []listingGroup{}
+	var found = []string{}
+	for _, name := range names {
+		var baseDepth = len(db.FindAncestorGroups(name))
+		for _, groupName := range append([]string{name}, db.FindDescendantGroups(name)...) {
+			if rrh.FindIn(groupName, found) {
+				continue
+			}
+			found = append(found, groupName)
+			results = append(results, listingGroup{name: groupName, depth: len(db.FindAncestorGroups(groupName)) - baseDepth})
+		}
 	}
-	return names
+	return results
 }
 
 /*
 FindResults returns the result list of list command.
 */
 func FindResults(db *rrh.Database, args []string) ([]*Result, error) {
-	groups := findGroupNames(db, args)
+	return FindResultsWithTags(db, args, []string{})
+}
+
+/*
+FindResultsWithTags returns the result list of list command.
+The repositories in the results satisfy all of the given tag selectors.
+*/
+func FindResultsWithTags(db *rrh.Database, args []string, tags []string) ([]*Result, error) {
+	return FindResultsWithFilter(db, args, tags, nil)
+}
+
+/*
+FindResultsWithFilter returns the result list of list command.
+The repositories in the results satisfy all of the given tag selectors, and the given filter.
+*/
+func FindResultsWithFilter(db *rrh.Database, args []string, tags []string, f *filter.Filter) ([]*Result, error) {
+	matcher := &repoMatcher{tags: tags, filter: f}
+	if len(args) > 0 {
+		selected, err := selector.Resolve(db, args)
+		if err != nil {
+			return nil, err
+		}
+		matcher.selected = selected
+	}
+	groups := findGroupNames(db, matcher.selected)
 	results := []*Result{}
 	for _, group := range groups {
-		var list, err = findList(db, group)
+		var list, err = findList(db, group.name, matcher)
 		if err != nil {
 			return nil, err
 		}
+		list.Depth = group.depth
 		results = append(results, list)
 	}
 	return results, nil
 }
 
-func findGroupNames(db *rrh.Database, args []string) []string {
-	if len(args) == 0 {
+func findGroupNames(db *rrh.Database, selected *selector.Result) []listingGroup {
+	if selected == nil || selected.IsAllGroups() {
 		return findAllGroupNames(db)
 	}
-	return args
+	return expandGroupNames(db, selected.Groups)
 }
@@ -2,10 +2,17 @@ package list
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/filter"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 func ExampleListCommand() {
@@ -51,7 +58,7 @@ func TestRunByCsvOutput(t *testing.T) {
 		cmd.Execute()
 
 		result := rrh.ReplaceNewline(buffer.String(), "&")
-		var want = "group1,desc1,repo1,,path1,,&group3,desc3,repo2,,path2,origin,git@github.com:example/repo2.git"
+		var want = "group1,desc1,repo1,,path1,,,,,,&group3,desc3,repo2,,path2,,,,,origin,git@github.com:example/repo2.git"
 		if result != want {
 			t.Errorf("result did not match, wont: %s, got: %s", want, result)
 		}
@@ -59,13 +66,47 @@ func TestRunByCsvOutput(t *testing.T) {
 	defer os.Remove(dbFile)
 }
 
+func TestListFormats(t *testing.T) {
+	var testdata = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-e", "id,path", "-f", `template={{.ID}}\t{{.Path}}`, "group1"}, "repo1\tpath1"},
+		{[]string{"-e", "id,path", "-f", "ndjson"}, `{"id":"repo1","path":"path1"}`},
+		{[]string{"-a", "-e", "id,path", "-f", "ndjson"}, `{"id":"repo1","path":"path1"}&{"id":"repo2","path":"path2"}`},
+		{[]string{"-e", "group,id,summary", "-f", "ndjson", "group1"}, `{"group-name":"group1","id":"repo1"}&{"summary":{"group-count":1,"repository-count":1,"actural-repository-count":1}}`},
+		{[]string{"-e", "group,id", "-f", "yaml", "group1"}, `groups:&  - group-name: "group1"&    repositories:&      - id: "repo1"`},
+		{[]string{"-e", "group,count,id,remote,summary", "-f", "json"}, `{"groups":[{"group-name":"group1","repository-count":1,"repositories":[{"id":"repo1","remotes":[]}]},` +
+			`{"group-name":"group2","repository-count":0,"repositories":[]},{"group-name":"group3","repository-count":1,"repositories":"abbreviated"}],` +
+			`"summary":{"group-count":3,"repository-count":2,"actural-repository-count":2}}`},
+		{[]string{"-a", "-e", "id,remote", "-f", "json", "group3"}, `{"groups":[{"repositories":[{"id":"repo2","remotes":[{"name":"origin","url":"git@github.com:example/repo2.git"}]}]}]}`},
+		{[]string{"-a", "-e", "id,remote", "-f", "yaml", "group3"}, `groups:&  - repositories:&      - id: "repo2"&        remotes:&          - name: "origin"&            url: "git@github.com:example/repo2.git"`},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(data.args)
+			cmd.SetOut(buffer)
+			cmd.Execute()
+			result := rrh.ReplaceNewline(buffer.String(), "&")
+			if result != data.want {
+				t.Errorf("%v: result did not match, wont: %s, got: %s", data.args, data.want, result)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
 func TestFindResults(t *testing.T) {
 	var testdata = []struct {
 		targets []string
 		want    []Result
 	}{
-		{[]string{"group1"}, []Result{{"group1", "desc1", false, []*Repo{{"repo1", "path1", "", []*rrh.Remote{}}}}}},
-		{[]string{"group2"}, []Result{{"group2", "desc2", false, []*Repo{}}}},
+		{[]string{"group1"}, []Result{{GroupName: "group1", Note: "desc1", Repos: []*Repo{{"repo1", "path1", "", []*rrh.Remote{}, []string{}, "", "", ""}}}}},
+		{[]string{"group2"}, []Result{{GroupName: "group2", Note: "desc2", Repos: []*Repo{}}}},
+		{[]string{"group*,!group3"}, []Result{{GroupName: "group1", Note: "desc1", Repos: []*Repo{{"repo1", "path1", "", []*rrh.Remote{}, []string{}, "", "", ""}}}}},
+		{[]string{"group1&group3"}, []Result{{GroupName: "group1", Note: "desc1", Repos: []*Repo{}}}},
 	}
 
 	for _, data := range testdata {
@@ -95,3 +136,202 @@ func TestFindResults(t *testing.T) {
 		defer os.Remove(dbFile)
 	}
 }
+
+func TestFindResultsWithTags(t *testing.T) {
+	var testdata = []struct {
+		tags      []string
+		wontRepos []string
+	}{
+		{[]string{}, []string{"repo1", "repo2"}},
+		{[]string{"lang=go"}, []string{"repo2"}},
+		{[]string{"!lang"}, []string{"repo1"}},
+		{[]string{"lang=rust"}, []string{}},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.TagRepository("repo2", []*rrh.Tag{{Key: "lang", Value: "go"}})
+			var results, err = FindResultsWithTags(db, []string{}, data.tags)
+			if err != nil {
+				t.Errorf("%v: unexpected error: %s", data.tags, err.Error())
+			}
+			var repos = []string{}
+			for _, result := range results {
+				for _, repo := range result.Repos {
+					repos = append(repos, repo.Name)
+				}
+			}
+			if len(repos) != len(data.wontRepos) {
+				t.Errorf("%v: repositories did not match, wont: %v, got: %v", data.tags, data.wontRepos, repos)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestFindResultsWithFilter(t *testing.T) {
+	var testdata = []struct {
+		expr      string
+		wontRepos []string
+	}{
+		{``, []string{"repo1", "repo2"}},
+		{`group == "group1"`, []string{"repo1"}},
+		{`remote =~ "github.com/example" && tag("lang")`, []string{"repo2"}},
+		{`id == "unknown"`, []string{}},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.TagRepository("repo2", []*rrh.Tag{{Key: "lang", Value: "go"}})
+			var f, _ = filter.Compile(data.expr)
+			var results, err = FindResultsWithFilter(db, []string{}, []string{}, f)
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", data.expr, err.Error())
+			}
+			var repos = []string{}
+			for _, result := range results {
+				for _, repo := range result.Repos {
+					repos = append(repos, repo.Name)
+				}
+			}
+			if strings.Join(repos, ",") != strings.Join(data.wontRepos, ",") {
+				t.Errorf("%s: repositories did not match, wont: %v, got: %v", data.expr, data.wontRepos, repos)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestListGroupHierarchy(t *testing.T) {
+	var testdata = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-e", "group,count,id"}, "group1 (1 repository, 2 in subtree)&    repo2&    group2 (1 repository)&        repo1&group3 (1 repository) (abbreviate repositories)&3 groups, and 3 repositories (actually 2 repositories)"},
+		{[]string{"-e", "group,count,id", "group2"}, "group2 (1 repository)&    repo1&1 group, and 1 repository"},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.Unrelate("group1", "repo1")
+			db.Relate("group2", "repo1")
+			db.Relate("group1", "repo2")
+			db.SetParentGroup("group2", "group1")
+			db.StoreAndClose()
+
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(data.args)
+			cmd.SetOut(buffer)
+			cmd.Execute()
+			result := rrh.ReplaceNewline(buffer.String(), "&")
+			if result != data.want {
+				t.Errorf("%v: result did not match, wont: %s, got: %s", data.args, data.want, result)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestListTree(t *testing.T) {
+	var testdata = []struct {
+		args      []string
+		wontError bool
+		want      string
+	}{
+		{[]string{"-f", "tree", "-e", "group,count,id,path,remote"}, false, "group1 (1 repository, 3 in subtree)&├── repo2    path2&│   └── origin    git@github.com:example/repo2.git&└── group2 (2 repositories)&    ├── repo1    path1&    └── リポ     path3&group3 (1 repository) (abbreviate repositories)"},
+		{[]string{"-f", "tree", "-e", "group,id,summary", "group2"}, false, "group2&├── repo1&└── リポ&1 group, and 2 repositories"},
+		{[]string{"-f", "tree", "-e", "id", "--badges", "group2"}, false, "├── repo1    [missing]&└── リポ     [missing]"},
+		{[]string{"-f", "csv", "--badges"}, true, ""},
+		{[]string{"-f", "tree", "-e", "group", "--sort", "group", "--reverse"}, false, "group3 (abbreviate repositories)&group1&└── group2"},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.Unrelate("group1", "repo1")
+			db.Relate("group2", "repo1")
+			db.Relate("group1", "repo2")
+			wide, _ := db.CreateRepository("リポ", "path3", "", []*rrh.Remote{})
+			wide.Path = "path3"
+			db.Relate("group2", "リポ")
+			db.SetParentGroup("group2", "group1")
+			db.StoreAndClose()
+
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(data.args)
+			cmd.SetOut(buffer)
+			cmd.SetErr(buffer)
+			err := cmd.Execute()
+			if err == nil && data.wontError || err != nil && !data.wontError {
+				t.Errorf("%v: wont error %v, but got %v", data.args, data.wontError, err)
+			}
+			if data.wontError {
+				return
+			}
+			result := rrh.ReplaceNewline(buffer.String(), "&")
+			if result != data.want {
+				t.Errorf("%v: result did not match, wont: %s, got: %s", data.args, data.want, result)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestStatusBadge(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "rrh-badge")
+	defer os.RemoveAll(dir)
+	repo := &Repo{Name: "badge", Path: dir}
+	if badge := statusBadge(repo); badge != "[missing]" {
+		t.Errorf("not git repository: wont [missing], got %s", badge)
+	}
+	r, _ := git.PlainInit(dir, false)
+	if badge := statusBadge(repo); badge != "[no branch]" {
+		t.Errorf("no commits: wont [no branch], got %s", badge)
+	}
+	file := filepath.Join(dir, "README")
+	ioutil.WriteFile(file, []byte("hello"), 0644)
+	w, _ := r.Worktree()
+	w.Add("README")
+	w.Commit("initial commit", &git.CommitOptions{Author: &object.Signature{Name: "rrh", Email: "rrh@example.com", When: time.Now()}})
+	if badge := statusBadge(repo); badge != "[master]" {
+		t.Errorf("clean: wont [master], got %s", badge)
+	}
+	ioutil.WriteFile(file, []byte("world"), 0644)
+	if badge := statusBadge(repo); badge != "[master *]" {
+		t.Errorf("dirty: wont [master *], got %s", badge)
+	}
+}
+
+func TestListSortByTimes(t *testing.T) {
+	os.Setenv(rrh.TimeFormat, "2006-01-02")
+	defer os.Unsetenv(rrh.TimeFormat)
+	var testdata = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-e", "id,last-accessed", "-f", "csv", "group1"}, "repo1,2001-09-09&repo2,2001-09-10"},
+		{[]string{"-e", "id,last-accessed", "-f", "csv", "--sort", "last-accessed", "group1"}, "repo2,2001-09-10&repo1,2001-09-09"},
+		{[]string{"-e", "id,added-at", "-f", "csv", "--sort", "added-at", "group1"}, "repo1,2001-09-09&repo2,"},
+		{[]string{"-e", "id", "-f", "csv", "--sort", "id", "group1"}, "repo1&repo2"},
+		{[]string{"-e", "id", "-f", "csv", "--sort", "last-accessed", "--reverse", "group1"}, "repo1&repo2"},
+		{[]string{"-e", "group,id", "-f", "csv", "--sort", "group", "-r"}, "group3,repo2&group1,repo1&group1,repo2"},
+	}
+	for _, data := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			var day1, day2 = rrh.Unix(1000050000, 0), rrh.Unix(1000050000+86400, 0)
+			db.Relate("group1", "repo2")
+			db.FindRepository("repo1").LastAccessed = &day1
+			db.FindRepository("repo1").AddedAt = &day1
+			db.FindRepository("repo2").LastAccessed = &day2
+			db.StoreAndClose()
+
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetArgs(data.args)
+			cmd.SetOut(buffer)
+			cmd.Execute()
+			result := rrh.ReplaceNewline(buffer.String(), "&")
+			if result != data.want {
+				t.Errorf("%v: result did not match, wont: %s, got: %s", data.args, data.want, result)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
@@ -0,0 +1,193 @@
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/decorator"
+)
+
+/*
+treeFormat prints the groups, the repositories, and the remotes in the tree with the box-drawing characters.
+The descendant groups are printed as the children of their parent group.
+*/
+type treeFormat struct {
+	deco   decorator.Decorator
+	badges bool
+}
+
+type treeNode struct {
+	label    string
+	children []*treeNode
+}
+
+func (node *treeNode) append(children ...*treeNode) {
+	node.children = append(node.children, children...)
+}
+
+func leaf(format string, args ...interface{}) *treeNode {
+	return &treeNode{label: fmt.Sprintf(format, args...)}
+}
+
+func (tf *treeFormat) Format(writer io.Writer, r []*Result, li Entries, noAbbrevFlag bool) error {
+	w := bufio.NewWriter(writer)
+	roots, _ := tf.buildGroups(r, 0, li, noAbbrevFlag)
+	for _, root := range roots {
+		w.WriteString(root.label + "\n")
+		writeChildren(w, root, "")
+	}
+	if li.IsSummary() {
+		(&defaultFormat{deco: tf.deco}).formatSummary(w, r)
+	}
+	return w.Flush()
+}
+
+/*
+buildGroups builds the nodes of the groups in the same depth from the head of the given results,
+and returns the nodes and the number of the consumed results.
+*/
+func (tf *treeFormat) buildGroups(r []*Result, depth int, li Entries, noAbbrevFlag bool) ([]*treeNode, int) {
+	nodes := []*treeNode{}
+	index := 0
+	for index < len(r) && r[index].Depth >= depth {
+		node := tf.buildGroup(r[index], li, noAbbrevFlag)
+		children, consumed := tf.buildGroups(r[index+1:], r[index].Depth+1, li, noAbbrevFlag)
+		node.append(children...)
+		nodes = append(nodes, node)
+		index = index + consumed + 1
+	}
+	return nodes, index
+}
+
+func (tf *treeFormat) buildGroup(r *Result, li Entries, noAbbrevFlag bool) *treeNode {
+	node := &treeNode{label: tf.groupLabel(r, li, noAbbrevFlag)}
+	if !noAbbrevFlag && r.Abbrev {
+		return node
+	}
+	if li.IsNote() {
+		node.append(leaf("Note: %s", r.Note))
+	}
+	width := computeDisplayWidth(r)
+	for _, repo := range r.Repos {
+		if child := tf.buildRepository(repo, li, width); child.label != "" || len(child.children) > 0 {
+			node.append(child)
+		}
+	}
+	return node
+}
+
+func (tf *treeFormat) groupLabel(r *Result, li Entries, noAbbrevFlag bool) string {
+	labels := []string{}
+	if li.IsGroupName() {
+		labels = append(labels, tf.deco.GroupName(r.GroupName))
+	}
+	if li.IsRepositoryCount() {
+		labels = append(labels, "("+formatCount(r)+")")
+	}
+	if !noAbbrevFlag && r.Abbrev {
+		labels = append(labels, "(abbreviate repositories)")
+	}
+	return strings.Join(labels, " ")
+}
+
+func (tf *treeFormat) buildRepository(repo *Repo, li Entries, width int) *treeNode {
+	node := &treeNode{label: tf.repositoryLabel(repo, li, width)}
+	if li.IsRepositoryDesc() {
+		node.append(leaf("Desc: %s", repo.Desc))
+	}
+	if li.IsRepositoryTags() && len(repo.Tags) > 0 {
+		node.append(leaf("Tags: %s", strings.Join(repo.Tags, ", ")))
+	}
+	if li.IsRepositoryAddedAt() && repo.AddedAt != "" {
+		node.append(leaf("Added at: %s", repo.AddedAt))
+	}
+	if li.IsRepositoryUpdatedAt() && repo.UpdatedAt != "" {
+		node.append(leaf("Updated at: %s", repo.UpdatedAt))
+	}
+	if li.IsRepositoryLastAccessed() && repo.LastAccessed != "" {
+		node.append(leaf("Last accessed: %s", repo.LastAccessed))
+	}
+	if li.IsRepositoryRemotes() {
+		remoteWidth := computeRemoteWidth(repo)
+		for _, remote := range repo.Remotes {
+			node.append(leaf("%s    %s", padRight(remote.Name, remote.Name, remoteWidth), remote.URL))
+		}
+	}
+	return node
+}
+
+func (tf *treeFormat) repositoryLabel(repo *Repo, li Entries, width int) string {
+	labels := []string{}
+	if li.IsRepositoryId() {
+		labels = append(labels, padRight(tf.deco.RepositoryID(repo.Name), repo.Name, width))
+	}
+	if li.IsRepositoryPath() {
+		labels = append(labels, repo.Path)
+	}
+	if tf.badges {
+		labels = append(labels, statusBadge(repo))
+	}
+	return strings.TrimRight(strings.Join(labels, "    "), " ")
+}
+
+var hashPattern = regexp.MustCompile("^[0-9a-f]{40}$")
+
+/*
+statusBadge returns the badge of the repository status, such as "[main]", "[main *]" (dirty), and "[missing]".
+*/
+func statusBadge(repo *Repo) string {
+	branch, err := rrh.CurrentBranch(repo.Path)
+	if err != nil {
+		if !rrh.IsGitRepository(repo.Path) {
+			return "[missing]"
+		}
+		branch = "no branch"
+	}
+	if hashPattern.MatchString(branch) {
+		branch = branch[:7]
+	}
+	if dirty, err := rrh.IsDirty(&rrh.Repository{ID: repo.Name, Path: repo.Path}); err == nil && dirty {
+		return fmt.Sprintf("[%s *]", branch)
+	}
+	return fmt.Sprintf("[%s]", branch)
+}
+
+func writeChildren(w *bufio.Writer, node *treeNode, prefix string) {
+	for i, child := range node.children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		w.WriteString(prefix + branch + child.label + "\n")
+		writeChildren(w, child, prefix+indent)
+	}
+}
+
+/*
+padRight pads the decorated string by the display width of the plain string,
+since the decorated string contains the escape sequences, and the wide characters occupy two columns.
+*/
+func padRight(decorated, plain string, width int) string {
+	return decorated + strings.Repeat(" ", rrh.MaxInt(width-runewidth.StringWidth(plain), 0))
+}
+
+func computeDisplayWidth(r *Result) int {
+	max := 0
+	for _, repo := range r.Repos {
+		max = rrh.MaxInt(runewidth.StringWidth(repo.Name), max)
+	}
+	return max
+}
+
+func computeRemoteWidth(repo *Repo) int {
+	max := 0
+	for _, remote := range repo.Remotes {
+		max = rrh.MaxInt(runewidth.StringWidth(remote.Name), max)
+	}
+	return max
+}
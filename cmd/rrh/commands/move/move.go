@@ -11,7 +11,8 @@ import (
 )
 
 type moveOptions struct {
-	dryRunFlag bool
+	dryRunFlag    bool
+	subgroupsFlag bool
 }
 
 var moveOpts = &moveOptions{}
@@ -20,12 +21,17 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "mv <FROM...> <TO>",
 		Short: "move the repositories from groups to another group",
-		Args:  cobra.MinimumNArgs(2),
+		Long: `move the repositories from groups to another group.
+When moving a group to another group, only the repositories directly belonging to the group are moved.
+Specify --subgroups to also move the child groups of the group under the destination group.`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: utils.CompleteGroupsAndRepositories,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performMove)
 		},
 	}
 	cmd.Flags().BoolVarP(&moveOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	cmd.Flags().BoolVarP(&moveOpts.subgroupsFlag, "subgroups", "s", false, "move the child groups of the source groups, too")
 	return cmd
 }
 
@@ -190,6 +196,11 @@ func convertToTarget(db *rrh.Database, froms []string, to string) ([]target, tar
 	return targetFrom, targetTo
 }
 
+func isDynamicGroup(db *rrh.Database, groupName string) bool {
+	group := db.FindGroup(groupName)
+	return group != nil && group.IsDynamic()
+}
+
 func moveRepositoryToRepository(db *rrh.Database, from target, to target) error {
 	if from.repositoryName != to.repositoryName {
 		return fmt.Errorf("repository name did not match: %s, %s", from.original, to.original)
@@ -197,11 +208,15 @@ func moveRepositoryToRepository(db *rrh.Database, from target, to target) error
 	if _, err := db.AutoCreateGroup(to.groupName, "", false); err != nil {
 		return err
 	}
+	if isDynamicGroup(db, to.groupName) {
+		return fmt.Errorf("%s: could not move to the dynamic group", to.groupName)
+	}
 	if from.kind == GroupAndRepoType {
-		db.Unrelate(from.groupName, from.repositoryName)
+		if err := db.Unrelate(from.groupName, from.repositoryName); err != nil {
+			return err
+		}
 	}
-	db.Relate(to.groupName, to.repositoryName)
-	return nil
+	return db.Relate(to.groupName, to.repositoryName)
 }
 
 func moveRepositoryToGroup(db *rrh.Database, from target, to target) error {
@@ -210,11 +225,15 @@ func moveRepositoryToGroup(db *rrh.Database, from target, to target) error {
 			return err
 		}
 	}
+	if isDynamicGroup(db, to.original) {
+		return fmt.Errorf("%s: could not move to the dynamic group", to.original)
+	}
 	if from.kind == GroupAndRepoType {
-		db.Unrelate(from.groupName, from.repositoryName)
+		if err := db.Unrelate(from.groupName, from.repositoryName); err != nil {
+			return err
+		}
 	}
-	db.Relate(to.original, from.repositoryName)
-	return nil
+	return db.Relate(to.original, from.repositoryName)
 }
 
 func moveRepositoriesToGroup(db *rrh.Database, froms []target, to target) error {
@@ -240,11 +259,19 @@ func moveGroupToGroup(db *rrh.Database, from target, to target) error {
 	if _, err := db.AutoCreateGroup(to.groupName, "", false); err != nil {
 		return err
 	}
+	if isDynamicGroup(db, from.groupName) || isDynamicGroup(db, to.groupName) {
+		return fmt.Errorf("%s, %s: could not move repositories of the dynamic groups", from.groupName, to.groupName)
+	}
 	var repos = db.FindRelationsOfGroup(from.groupName)
 	for _, repo := range repos {
 		db.Unrelate(from.groupName, repo)
 		db.Relate(to.groupName, repo)
 	}
+	if moveOpts.subgroupsFlag {
+		for _, child := range db.FindChildGroups(from.groupName) {
+			el = el.Append(db.SetParentGroup(child.Name, to.groupName))
+		}
+	}
 	return el.NilOrThis()
 }
 
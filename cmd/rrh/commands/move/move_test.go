@@ -83,6 +83,35 @@ func TestMoveCommand(t *testing.T) {
 	}
 }
 
+func TestMoveGroupWithSubgroups(t *testing.T) {
+	var cases = []struct {
+		args       []string
+		wontParent string
+	}{
+		{[]string{"group1", "group3"}, "group1"},
+		{[]string{"--subgroups", "group1", "group3"}, "group3"},
+	}
+	for _, item := range cases {
+		dbFile := rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
+			oldDB.SetParentGroup("group2", "group1")
+			oldDB.StoreAndClose()
+			moveOpts.subgroupsFlag = false
+			cmd := New()
+			cmd.SetArgs(item.args)
+			cmd.Execute()
+
+			var db, _ = rrh.Open(config)
+			if !db.HasRelation("group3", "repo1") {
+				t.Errorf("rrh mv %v failed: repo1 was not moved to group3", item.args)
+			}
+			if parent := db.FindGroup("group2").Parent; parent != item.wontParent {
+				t.Errorf("rrh mv %v: parent of group2 did not match, wont: %s, got: %s", item.args, item.wontParent, parent)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
 func TestParseType(t *testing.T) {
 	var cases = []struct {
 		gives     string
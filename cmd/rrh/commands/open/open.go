@@ -1,7 +1,9 @@
 package open
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/skratchdot/open-golang/open"
@@ -9,84 +11,223 @@ import (
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
 	"github.com/tamada/rrh/common"
+	"github.com/tamada/rrh/selector"
 )
 
 func New() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "open",
+		Use:   "open [REPOSITORY_IDs...]",
 		Short: "open the folder or web page of the given repositories",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `open the folder or web page of the given repositories.
+The web page is built from the remote url by the layout of the forge (GitHub, GitLab, Gitea/Forgejo, and Bitbucket).
+The forge is decided by the host of the remote url, and the forges of the custom hosts are specified in RRH_FORGES
+(e.g., git.example.com=gitlab,code.example.org=forgejo).
+The --remote, --branch, --path, --issues, and --pulls options imply --browser.
+
+The --editor, --terminal, and --with options open the repositories by the openers, which are the command templates.
+The editor opener is RRH_EDITOR_COMMAND (or $VISUAL, $EDITOR), the terminal opener is RRH_TERMINAL_COMMAND,
+and the other openers are defined in RRH_OPENERS (e.g., idea=idea {{.Path}};tig=tig -C {{.Path}}).
+The templates refer {{.ID}} and {{.Path}} of each repository.
+If the template refers {{.IDs}}, {{.Paths}}, or {{.Workspace}} (the multi-root workspace file, such as code {{.Workspace}}),
+all of the repositories are opened at once.`,
+		Args:              validateArgs,
+		ValidArgsFunction: utils.CompleteRepositories,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performOpen)
 		},
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&openOpts.webpageFlag, "browser", "b", false, "open the web page of the repository")
+	flags.StringSliceVarP(&openOpts.groups, "groups", "g", []string{}, "open the repositories of the group selectors, such as work,!legacy, go&backend, and team-*")
+	flags.StringVarP(&openOpts.remote, "remote", "r", "", "specify the remote name for the web page. Default is origin, or the first remote")
+	flags.StringVarP(&openOpts.branch, "branch", "", "", "open the web page of the given branch")
+	flags.StringVarP(&openOpts.path, "path", "", "", "open the web page of the given file in FILE[:LINE] form")
+	flags.BoolVarP(&openOpts.issues, "issues", "", false, "open the web page of the issues")
+	flags.BoolVarP(&openOpts.pulls, "pulls", "", false, "open the web page of the pull (merge) requests")
+	flags.BoolVarP(&openOpts.editor, "editor", "e", false, "open the repositories by the editor (RRH_EDITOR_COMMAND)")
+	flags.BoolVarP(&openOpts.terminal, "terminal", "t", false, "open the repositories by the terminal (RRH_TERMINAL_COMMAND)")
+	flags.StringVarP(&openOpts.with, "with", "w", "", "open the repositories by the given opener (editor, terminal, or the name in RRH_OPENERS)")
+	flags.BoolVarP(&openOpts.printFlag, "print", "p", false, "print the path, the url, or the opener command instead of opening it")
+	cmd.RegisterFlagCompletionFunc("groups", utils.CompleteGroups)
+	cmd.RegisterFlagCompletionFunc("remote", utils.CompleteRemotes)
+	cmd.RegisterFlagCompletionFunc("with", completeOpeners)
 	return cmd
 }
 
 type openOptions struct {
 	webpageFlag bool
+	groups      []string
+	remote      string
+	branch      string
+	path        string
+	issues      bool
+	pulls       bool
+	editor      bool
+	terminal    bool
+	with        string
+	printFlag   bool
 }
 
 var openOpts = &openOptions{}
 
-func convertToRepositoryURL(url string) (string, error) {
-	str := strings.TrimPrefix(url, "git@")
-	str = strings.TrimSuffix(str, ".git")
-	index := strings.Index(str, ":")
+func validateArgs(c *cobra.Command, args []string) error {
+	if len(args) == 0 && len(openOpts.groups) == 0 {
+		return errors.New("either of repositories and groups option should be specified")
+	}
+	if openOpts.issues && openOpts.pulls {
+		return errors.New("issues and pulls options are exclusive")
+	}
+	if (openOpts.issues || openOpts.pulls) && openOpts.path != "" {
+		return errors.New("path option could not be specified with issues and pulls options")
+	}
+	return validateOpeners()
+}
+
+func validateOpeners() error {
+	var count = 0
+	for _, flag := range []bool{openOpts.editor, openOpts.terminal, openOpts.with != ""} {
+		if flag {
+			count++
+		}
+	}
+	if count > 1 {
+		return errors.New("editor, terminal, and with options are exclusive")
+	}
+	if count == 1 && isBrowserTarget() {
+		return errors.New("editor, terminal, and with options could not be specified with the options for the web page")
+	}
+	return nil
+}
+
+func isBrowserTarget() bool {
+	return openOpts.webpageFlag || openOpts.remote != "" || openOpts.branch != "" ||
+		openOpts.path != "" || openOpts.issues || openOpts.pulls
+}
+
+/*
+parsePath parses the given string in FILE[:LINE] form.
+The line is 0 if no line number is given.
+*/
+func parsePath(str string) (string, int) {
+	var index = strings.LastIndex(str, ":")
 	if index < 0 {
-		return "", fmt.Errorf("%s: unrecognized git repository url", url)
+		return str, 0
 	}
-	host := str[0:index]
-	return "https://" + host + "/" + str[index+1:], nil
+	var line, err = strconv.Atoi(str[index+1:])
+	if err != nil || line < 0 {
+		return str, 0
+	}
+	return str[:index], line
 }
 
-func convertURL(url string) (string, error) {
-	if strings.HasPrefix(url, "git@") {
-		convertedURL, err := convertToRepositoryURL(url)
-		if err != nil {
-			return "", err
+func findRemote(repo *rrh.Repository) (*rrh.Remote, error) {
+	if openOpts.remote == "" {
+		if remote := repo.PrimaryRemote(); remote != nil {
+			return remote, nil
+		}
+		return nil, fmt.Errorf("%s: remote repository not found", repo.ID)
+	}
+	for _, remote := range repo.Remotes {
+		if remote.Name == openOpts.remote {
+			return remote, nil
 		}
-		url = convertedURL
 	}
-	if strings.HasPrefix(url, "https") && strings.HasSuffix(url, ".git") {
-		url = strings.TrimSuffix(url, ".git")
+	return nil, fmt.Errorf("%s: %s: remote not found", repo.ID, openOpts.remote)
+}
+
+func newBrowseOption(repo *rrh.Repository) *rrh.BrowseOption {
+	var path, line = parsePath(openOpts.path)
+	var branch = openOpts.branch
+	if branch == "" && path != "" {
+		branch, _ = rrh.CurrentBranch(repo.Path)
 	}
-	return url, nil
+	return &rrh.BrowseOption{Branch: branch, Path: path, Line: line, Issues: openOpts.issues, Pulls: openOpts.pulls}
 }
 
-func generateWebPageURL(repo *rrh.Repository) (string, error) {
-	if len(repo.Remotes) == 0 {
-		return "", fmt.Errorf("%s: remote repository not found", repo.ID)
+func generateWebPageURL(repo *rrh.Repository, config *rrh.Config) (string, error) {
+	remote, err := findRemote(repo)
+	if err != nil {
+		return "", err
 	}
-	return convertURL(repo.Remotes[0].URL)
+	var url = rrh.ParseRemoteURL(remote.URL)
+	return url.BrowserURL(rrh.FindForge(config, url.Host), newBrowseOption(repo))
 }
 
-func execOpen(repo *rrh.Repository) (string, error) {
-	if openOpts.webpageFlag {
-		return generateWebPageURL(repo)
+func execOpen(repo *rrh.Repository, config *rrh.Config) (string, error) {
+	if isBrowserTarget() {
+		return generateWebPageURL(repo, config)
 	}
 	return repo.Path, nil
 }
 
-func performEach(arg string, db *rrh.Database) error {
+func performEach(c *cobra.Command, arg string, db *rrh.Database) error {
 	repo := db.FindRepository(arg)
 	if repo == nil {
 		return fmt.Errorf("%s: repository not found", arg)
 	}
-	path, err := execOpen(repo)
+	path, err := execOpen(repo, db.Config)
 	if err != nil {
 		return err
 	}
-	return open.Start(path)
+	if openOpts.printFlag {
+		c.Println(path)
+		return nil
+	}
+	if err := open.Start(path); err != nil {
+		return err
+	}
+	return db.Touch(repo.ID)
+}
+
+func findTargetRepositories(args []string, db *rrh.Database) ([]string, error) {
+	if len(openOpts.groups) == 0 {
+		return args, nil
+	}
+	result, err := selector.Resolve(db, openOpts.groups)
+	if err != nil {
+		return nil, err
+	}
+	targets := append([]string{}, args...)
+	for _, repoID := range result.Repositories {
+		if !rrh.FindIn(repoID, targets) {
+			targets = append(targets, repoID)
+		}
+	}
+	return targets, nil
+}
+
+func performOpenerOn(c *cobra.Command, name string, args, targets []string, db *rrh.Database) error {
+	el := common.NewErrorList()
+	repos := []*rrh.Repository{}
+	for _, arg := range targets {
+		if repo := db.FindRepository(arg); repo != nil {
+			repos = append(repos, repo)
+		} else {
+			el = el.Append(fmt.Errorf("%s: repository not found", arg))
+		}
+	}
+	if el.IsErr() {
+		return el
+	}
+	return performOpener(c, name, args, repos, db)
 }
 
 func performOpen(c *cobra.Command, args []string, db *rrh.Database) error {
+	targets, err := findTargetRepositories(args, db)
+	if err != nil {
+		return err
+	}
 	el := common.NewErrorList()
-	for _, arg := range args {
-		err := performEach(arg, db)
-		el = el.Append(err)
+	if name := findOpenerName(); name != "" {
+		el = el.Append(performOpenerOn(c, name, args, targets, db))
+	} else {
+		for _, arg := range targets {
+			err := performEach(c, arg, db)
+			el = el.Append(err)
+		}
+	}
+	if !openOpts.printFlag {
+		el = el.Append(db.StoreAndClose())
 	}
 	return el.NilOrThis()
 }
@@ -1,7 +1,10 @@
 package open
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
@@ -20,22 +23,86 @@ func TestExecute(t *testing.T) {
 	defer os.Remove(dbFile)
 }
 
-func TestConvertGitURL(t *testing.T) {
+func TestFindTargetRepositories(t *testing.T) {
 	testdata := []struct {
-		giveString string
-		errorFlag  bool
-		wontString string
+		args      []string
+		groups    []string
+		wontError bool
+		wontRepos []string
 	}{
-		{"git@github.com:tamada/rrh.git", false, `https://github.com/tamada/rrh`},
-		{"git@github.com:tamada/rrh", false, `https://github.com/tamada/rrh`},
+		{[]string{"repo1"}, []string{}, false, []string{"repo1"}},
+		{[]string{}, []string{"group*", "!group1"}, false, []string{"repo2"}},
+		{[]string{"repo2"}, []string{"group1&group3"}, false, []string{"repo2"}},
+		{[]string{"repo2"}, []string{"group1"}, false, []string{"repo2", "repo1"}},
+		{[]string{}, []string{"unknown"}, true, []string{}},
 	}
 	for _, td := range testdata {
-		url, err := convertToRepositoryURL(td.giveString)
-		if (err == nil) == td.errorFlag {
-			t.Errorf("convertToRepositoryURL(%s) should be %v, but %v", td.giveString, td.errorFlag, err)
-		}
-		if url != td.wontString {
-			t.Errorf("convertToRepositoryURL(%s) wont %s, but got %s", td.giveString, td.wontString, url)
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			openOpts.groups = td.groups
+			repos, err := findTargetRepositories(td.args, db)
+			if (err != nil) != td.wontError {
+				t.Errorf("%v, %v: wont error %v, but got %v", td.args, td.groups, td.wontError, err)
+			}
+			if err == nil && strings.Join(repos, ",") != strings.Join(td.wontRepos, ",") {
+				t.Errorf("%v, %v: repositories did not match, wont: %v, got: %v", td.args, td.groups, td.wontRepos, repos)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+	openOpts.groups = []string{}
+}
+
+func TestPrintURL(t *testing.T) {
+	testdata := []struct {
+		args      []string
+		wontError bool
+		wont      string
+	}{
+		{[]string{"--print", "repo1"}, false, "path1\n"},
+		{[]string{"-p", "-b", "repo2"}, false, "https://github.com/example/repo2\n"},
+		{[]string{"-p", "--branch", "main", "repo2"}, false, "https://github.com/example/repo2/tree/main\n"},
+		{[]string{"-p", "--branch", "main", "--path", "README.md:12", "repo2"}, false, "https://github.com/example/repo2/blob/main/README.md#L12\n"},
+		{[]string{"-p", "--issues", "-r", "origin", "repo2"}, false, "https://github.com/example/repo2/issues\n"},
+		{[]string{"-p", "--pulls", "-g", "group3"}, false, "https://github.com/example/repo2/pulls\n"},
+		{[]string{"-p", "-b", "repo1"}, true, ""},
+		{[]string{"-p", "-r", "upstream", "repo2"}, true, ""},
+		{[]string{"-p", "--issues", "--pulls", "repo2"}, true, ""},
+		{[]string{"-p", "--issues", "--path", "go.mod", "repo2"}, true, ""},
+	}
+	for _, td := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetOut(buffer)
+			cmd.SetErr(ioutil.Discard)
+			cmd.SetArgs(td.args)
+			err := cmd.Execute()
+			if (err != nil) != td.wontError {
+				t.Errorf("%v: wont error %v, but got %v", td.args, td.wontError, err)
+			}
+			if err == nil && buffer.String() != td.wont {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", td.args, td.wont, buffer.String())
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+	openOpts.groups = []string{}
+}
+
+func TestParsePath(t *testing.T) {
+	testdata := []struct {
+		give     string
+		wontPath string
+		wontLine int
+	}{
+		{"README.md", "README.md", 0},
+		{"src/main.go:42", "src/main.go", 42},
+		{"src/main.go:abc", "src/main.go:abc", 0},
+	}
+	for _, td := range testdata {
+		path, line := parsePath(td.give)
+		if path != td.wontPath || line != td.wontLine {
+			t.Errorf("parsePath(%s) wont %s, %d, but got %s, %d", td.give, td.wontPath, td.wontLine, path, line)
 		}
 	}
 }
@@ -0,0 +1,288 @@
+package open
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/common"
+)
+
+/*
+OpenerData represents the values for the command templates of the openers.
+ID and Path are the values of the repository, and they are of the first repository if the template opens all of the repositories at once.
+IDs and Paths are the values of all of the target repositories,
+and Workspace is the path of the multi-root workspace file (.code-workspace) of them.
+*/
+type OpenerData struct {
+	ID        string
+	Path      string
+	IDs       []string
+	Paths     []string
+	Workspace string
+}
+
+/*
+findOpenerName returns the name of the opener specified by the options, or the empty string if no openers are specified.
+*/
+func findOpenerName() string {
+	if openOpts.editor {
+		return rrh.EditorOpener
+	}
+	if openOpts.terminal {
+		return rrh.TerminalOpener
+	}
+	return openOpts.with
+}
+
+/*
+isAllAtOnce returns true if the given template opens all of the repositories at once.
+*/
+func isAllAtOnce(command string) bool {
+	return strings.Contains(command, ".IDs") || strings.Contains(command, ".Paths") || strings.Contains(command, ".Workspace")
+}
+
+/*
+buildArgs builds the arguments of the command from the given template.
+The template is split into the arguments by white spaces before expanding (see splitTemplate),
+therefore, the paths containing spaces are passed as one argument.
+The arguments of only {{.IDs}} or {{.Paths}} are expanded to the multiple arguments.
+*/
+func buildArgs(command string, data *OpenerData) ([]string, error) {
+	var fields, err = splitTemplate(command)
+	if err != nil {
+		return nil, err
+	}
+	var args = []string{}
+	for _, field := range fields {
+		switch listField(field) {
+		case "IDs":
+			args = append(args, data.IDs...)
+		case "Paths":
+			args = append(args, data.Paths...)
+		default:
+			var arg, err = expand(field, data)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s: empty opener command", command)
+	}
+	return args, nil
+}
+
+var listFieldPattern = regexp.MustCompile(`^\{\{-?\s*\.(IDs|Paths)\s*-?\}\}$`)
+
+/*
+listField returns the name of the list field (IDs or Paths) if the given argument consists of only it,
+otherwise returns the empty string.
+*/
+func listField(field string) string {
+	if matches := listFieldPattern.FindStringSubmatch(field); matches != nil {
+		return matches[1]
+	}
+	return ""
+}
+
+/*
+splitTemplate splits the given template into the arguments by white spaces like the shell.
+The white spaces in the actions ({{ ... }}) and the quoted strings ('...' or "...") do not split the arguments,
+and the quotes out of the actions are removed (e.g., "{{.Path}}/sub dir" is one argument).
+*/
+func splitTemplate(command string) ([]string, error) {
+	var fields = []string{}
+	var builder = new(strings.Builder)
+	var inField, inAction = false, false
+	var quote rune
+	var runes = []rune(command)
+	for i := 0; i < len(runes); i++ {
+		var r = runes[i]
+		switch {
+		case inAction:
+			builder.WriteRune(r)
+			if r == '}' && i+1 < len(runes) && runes[i+1] == '}' {
+				builder.WriteRune('}')
+				i++
+				inAction = false
+			}
+		case r == '{' && i+1 < len(runes) && runes[i+1] == '{':
+			builder.WriteString("{{")
+			i++
+			inField, inAction = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			builder.WriteRune(r)
+		case r == '\'' || r == '"':
+			inField, quote = true, r
+		case unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, builder.String())
+				builder.Reset()
+			}
+			inField = false
+		default:
+			builder.WriteRune(r)
+			inField = true
+		}
+	}
+	if quote != 0 || inAction {
+		return nil, fmt.Errorf("%s: unterminated quote or action", command)
+	}
+	if inField {
+		fields = append(fields, builder.String())
+	}
+	return fields, nil
+}
+
+func expand(field string, data *OpenerData) (string, error) {
+	var t, err = template.New("opener").Parse(field)
+	if err != nil {
+		return "", err
+	}
+	var builder = new(strings.Builder)
+	if err := t.Execute(builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9.+_-]+`)
+
+/*
+workspaceName returns the name of the workspace file from the group selectors, or the repository ids.
+*/
+func workspaceName(args []string, repos []*rrh.Repository) string {
+	var names = openOpts.groups
+	if len(args) > 0 || len(names) == 0 {
+		names = []string{}
+		for _, repo := range repos {
+			names = append(names, repo.ID)
+		}
+	}
+	return invalidNameChars.ReplaceAllString(strings.Join(names, "+"), "_")
+}
+
+/*
+writeWorkspace writes the multi-root workspace file of the given repositories into ${RRH_HOME}/workspaces,
+and returns the path of it.
+*/
+func writeWorkspace(config *rrh.Config, name string, repos []*rrh.Repository) (string, error) {
+	type folder struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+	}
+	var folders = []folder{}
+	for _, repo := range repos {
+		folders = append(folders, folder{Name: repo.ID, Path: repo.Path})
+	}
+	var data, err = json.MarshalIndent(map[string][]folder{"folders": folders}, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	var path = filepath.Join(config.GetValue(rrh.Home), "workspaces", name+".code-workspace")
+	if err := rrh.CreateParentDir(path); err != nil {
+		return "", err
+	}
+	return path, ioutil.WriteFile(path, data, 0644)
+}
+
+func newOpenerData(config *rrh.Config, command string, args []string, repos []*rrh.Repository) (*OpenerData, error) {
+	var data = &OpenerData{ID: repos[0].ID, Path: repos[0].Path, IDs: []string{}, Paths: []string{}}
+	for _, repo := range repos {
+		data.IDs = append(data.IDs, repo.ID)
+		data.Paths = append(data.Paths, repo.Path)
+	}
+	if strings.Contains(command, ".Workspace") {
+		var path, err = writeWorkspace(config, workspaceName(args, repos), repos)
+		if err != nil {
+			return nil, err
+		}
+		data.Workspace = path
+	}
+	return data, nil
+}
+
+func runOpener(c *cobra.Command, command string, data *OpenerData) error {
+	var args, err = buildArgs(command, data)
+	if err != nil {
+		return err
+	}
+	if openOpts.printFlag {
+		c.Println(strings.Join(args, " "))
+		return nil
+	}
+	var cmd = exec.Command(args[0], args[1:]...)
+	cmd.Stdin = c.InOrStdin()
+	cmd.Stdout = c.OutOrStdout()
+	cmd.Stderr = c.ErrOrStderr()
+	return cmd.Run()
+}
+
+/*
+performOpener opens the given repositories by the opener of the given name.
+If the command template of the opener refers to IDs, Paths, or Workspace, the repositories are opened at once,
+otherwise, the command runs in each repository.
+*/
+func performOpener(c *cobra.Command, name string, args []string, repos []*rrh.Repository, db *rrh.Database) error {
+	var command, err = db.Config.FindOpener(name)
+	if err != nil || len(repos) == 0 {
+		return err
+	}
+	var groups = [][]*rrh.Repository{repos}
+	if !isAllAtOnce(command) {
+		groups = [][]*rrh.Repository{}
+		for _, repo := range repos {
+			groups = append(groups, []*rrh.Repository{repo})
+		}
+	}
+	var el = common.NewErrorList()
+	for _, targets := range groups {
+		var data, err = newOpenerData(db.Config, command, args, targets)
+		if err == nil {
+			err = runOpener(c, command, data)
+		}
+		if err != nil {
+			el = el.Append(err)
+			continue
+		}
+		if !openOpts.printFlag {
+			for _, repo := range targets {
+				el = el.Append(db.Touch(repo.ID))
+			}
+		}
+	}
+	return el.NilOrThis()
+}
+
+/*
+completeOpeners completes the names of the built-in openers and the openers in RRH_OPENERS.
+*/
+func completeOpeners(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names = []string{rrh.EditorOpener, rrh.TerminalOpener}
+	if openers, err := rrh.ParseOpeners(rrh.OpenConfig().GetValue(rrh.Openers)); err == nil {
+		for name := range openers {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var results = []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			results = append(results, name)
+		}
+	}
+	return results, cobra.ShellCompDirectiveNoFileComp
+}
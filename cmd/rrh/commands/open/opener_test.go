@@ -0,0 +1,94 @@
+package open
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+)
+
+func TestOpeners(t *testing.T) {
+	var home, _ = ioutil.TempDir("", "rrh-opener")
+	defer os.RemoveAll(home)
+	os.Setenv(rrh.EditorCommand, "code --new-window {{.Path}}")
+	os.Setenv(rrh.TerminalCommand, "wezterm start --cwd {{.Path}} --class rrh-{{.ID}}")
+	os.Setenv(rrh.Openers, "multi=code {{.Paths}};ws=code {{.Workspace}}")
+	os.Setenv(rrh.Home, home)
+	defer os.Unsetenv(rrh.Home)
+	defer os.Unsetenv(rrh.EditorCommand)
+	defer os.Unsetenv(rrh.TerminalCommand)
+	defer os.Unsetenv(rrh.Openers)
+	var workspace = filepath.Join(home, "workspaces", "group1+group3.code-workspace")
+
+	testdata := []struct {
+		args      []string
+		wontError bool
+		wont      string
+	}{
+		{[]string{"-p", "--editor", "repo1", "repo2"}, false, "code --new-window path1&code --new-window path2"},
+		{[]string{"-p", "-t", "repo2"}, false, "wezterm start --cwd path2 --class rrh-repo2"},
+		{[]string{"-p", "--with", "multi", "-g", "group1,group3"}, false, "code path1 path2"},
+		{[]string{"-p", "--with", "ws", "-g", "group1,group3"}, false, "code " + workspace},
+		{[]string{"-p", "--with", "unknown", "repo1"}, true, ""},
+		{[]string{"-p", "--editor", "--terminal", "repo1"}, true, ""},
+		{[]string{"-p", "--editor", "--issues", "repo2"}, true, ""},
+		{[]string{"-p", "--editor", "repo1", "unknown"}, true, ""},
+	}
+	for _, td := range testdata {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			buffer := bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetOut(buffer)
+			cmd.SetErr(ioutil.Discard)
+			cmd.SetArgs(td.args)
+			err := cmd.Execute()
+			if (err != nil) != td.wontError {
+				t.Errorf("%v: wont error %v, but got %v", td.args, td.wontError, err)
+			}
+			if got := rrh.ReplaceNewline(buffer.String(), "&"); err == nil && got != td.wont {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", td.args, td.wont, got)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+	var data, err = ioutil.ReadFile(workspace)
+	if err != nil || !strings.Contains(string(data), `"path": "path2"`) {
+		t.Errorf("workspace file did not match: %s, %v", string(data), err)
+	}
+}
+
+func TestBuildArgs(t *testing.T) {
+	var data = &OpenerData{ID: "repo1", Path: "/src/my repo", IDs: []string{"repo1", "repo2"}, Paths: []string{"/src/my repo", "/src/repo2"}}
+	testdata := []struct {
+		command   string
+		wontError bool
+		wont      []string
+	}{
+		{"vim {{.Path}}", false, []string{"vim", "/src/my repo"}},
+		{"tmux new-session -s {{.ID}} -c {{.Path}}", false, []string{"tmux", "new-session", "-s", "repo1", "-c", "/src/my repo"}},
+		{"code {{.Paths}}", false, []string{"code", "/src/my repo", "/src/repo2"}},
+		{"code {{ .Path }}", false, []string{"code", "/src/my repo"}},
+		{"code {{ .Paths }}", false, []string{"code", "/src/my repo", "/src/repo2"}},
+		{"code {{index .Paths 0}}", false, []string{"code", "/src/my repo"}},
+		{`code {{printf "%s/sub" .Path}}`, false, []string{"code", "/src/my repo/sub"}},
+		{`sh -c 'cd "{{.Path}}" && make' --name "rrh {{.ID}}"`, false, []string{"sh", "-c", `cd "/src/my repo" && make`, "--name", "rrh repo1"}},
+		{`echo "" x`, false, []string{"echo", "", "x"}},
+		{`echo 'unterminated`, true, []string{}},
+		{"echo {{.Path", true, []string{}},
+		{"echo {{.Unknown}}", true, []string{}},
+		{"  ", true, []string{}},
+	}
+	for _, td := range testdata {
+		var args, err = buildArgs(td.command, data)
+		if (err != nil) != td.wontError {
+			t.Errorf("buildArgs(%s) wont error %v, but got %v", td.command, td.wontError, err)
+		}
+		if err == nil && strings.Join(args, ",") != strings.Join(td.wont, ",") {
+			t.Errorf("buildArgs(%s) did not match, wont: %v, got: %v", td.command, td.wont, args)
+		}
+	}
+}
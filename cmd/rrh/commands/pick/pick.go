@@ -0,0 +1,82 @@
+package pick
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+type pickOptions struct {
+	idFlag bool
+}
+
+var pickOpts = &pickOptions{}
+
+func New() *cobra.Command {
+	pickCommand := &cobra.Command{
+		Use:   "pick [QUERIES...]",
+		Short: "pick the repository by the built-in interactive fuzzy finder",
+		Long: `pick the repository by the built-in interactive fuzzy finder, and print the path of the selected repository.
+The repositories are fuzzy matched to the query by the id, the path, and the groups,
+and ranked by the frecency (see "rrh jump --help"). The arguments are the initial query.
+The preview shows the groups, the path, the remotes, and the status of the selected repository.
+Keys: type to filter, Up/Ctrl-P and Down/Ctrl-N to move, Enter to select,
+Backspace and Ctrl-U to edit the query, and Esc/Ctrl-C to cancel.`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	flags := pickCommand.Flags()
+	flags.BoolVarP(&pickOpts.idFlag, "id", "i", false, "print the id of the selected repository instead of the path")
+
+	return pickCommand
+}
+
+/*
+run shows the picker on the given terminal, and returns the selected repository.
+If the selection is canceled, this function returns nil.
+*/
+func run(t *terminal, p *picker) (*rrh.Repository, error) {
+	t.Write([]byte("\x1b[?1049h"))
+	defer t.Write([]byte("\x1b[?1049l"))
+	var buffer = make([]byte, 64)
+	for {
+		var width, height = t.Size()
+		p.render(t, width, height)
+		var n, err = t.Read(buffer)
+		if err != nil {
+			return nil, err
+		}
+		switch p.handle(buffer[:n]) {
+		case actionSelect:
+			return p.selected(), nil
+		case actionCancel:
+			return nil, nil
+		}
+	}
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	var t, err = openTerminal()
+	if err != nil {
+		return err
+	}
+	repo, err := run(t, newPicker(db, strings.Join(args, " "), time.Now()))
+	t.Close()
+	if err != nil {
+		return err
+	}
+	if repo == nil {
+		return errors.New("canceled")
+	}
+	if pickOpts.idFlag {
+		c.Println(repo.ID)
+	} else {
+		c.Println(repo.Path)
+	}
+	return nil
+}
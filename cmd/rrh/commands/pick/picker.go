@@ -0,0 +1,186 @@
+package pick
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/jump"
+)
+
+type action int
+
+const (
+	actionNone action = iota
+	actionSelect
+	actionCancel
+)
+
+/*
+picker is the model of the interactive repository picker.
+The model is independent from the terminal, the terminal sends the key inputs, and shows the rendered result.
+*/
+type picker struct {
+	db       *rrh.Database
+	now      time.Time
+	query    []rune
+	matched  []*jump.Candidate
+	cursor   int
+	statuses map[string]string
+}
+
+func newPicker(db *rrh.Database, query string, now time.Time) *picker {
+	var p = &picker{db: db, now: now, query: []rune(query), statuses: map[string]string{}}
+	p.update()
+	return p
+}
+
+/*
+update filters the repositories by the current query, and resets the cursor.
+*/
+func (p *picker) update() {
+	p.matched = jump.Rank(p.db, strings.Fields(string(p.query)), p.now)
+	p.cursor = 0
+}
+
+func (p *picker) selected() *rrh.Repository {
+	if p.cursor < 0 || p.cursor >= len(p.matched) {
+		return nil
+	}
+	return p.matched[p.cursor].Repository
+}
+
+func (p *picker) moveCursor(delta int) {
+	p.cursor = rrh.MaxInt(0, p.cursor+delta)
+	if p.cursor >= len(p.matched) {
+		p.cursor = rrh.MaxInt(0, len(p.matched)-1)
+	}
+}
+
+/*
+handle updates the model by the given key input, and returns the action for the terminal.
+The available keys are: printable characters (edit the query), Backspace, Ctrl-U (clear the query),
+Up/Ctrl-P, Down/Ctrl-N, Enter (select), and Esc/Ctrl-C/Ctrl-G (cancel).
+*/
+func (p *picker) handle(key []byte) action {
+	switch string(key) {
+	case "\r", "\n":
+		if p.selected() == nil {
+			return actionNone
+		}
+		return actionSelect
+	case "\x1b", "\x03", "\x07":
+		return actionCancel
+	case "\x1b[A", "\x1bOA", "\x10":
+		p.moveCursor(-1)
+	case "\x1b[B", "\x1bOB", "\x0e":
+		p.moveCursor(1)
+	case "\x7f", "\x08":
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.update()
+		}
+	case "\x15":
+		p.query = []rune{}
+		p.update()
+	default:
+		p.appendQuery(key)
+	}
+	return actionNone
+}
+
+func (p *picker) appendQuery(key []byte) {
+	var changed = false
+	for _, r := range string(key) {
+		if r >= ' ' && r != 0x7f {
+			p.query = append(p.query, r)
+			changed = true
+		}
+	}
+	if changed {
+		p.update()
+	}
+}
+
+func (p *picker) status(repo *rrh.Repository) string {
+	if status, ok := p.statuses[repo.ID]; ok {
+		return status
+	}
+	var status = "clean"
+	if dirty, err := rrh.IsDirty(repo); err != nil {
+		status = "unknown"
+	} else if dirty {
+		status = "dirty"
+	}
+	p.statuses[repo.ID] = status
+	return status
+}
+
+/*
+preview returns the lines showing the detail of the selected repository.
+*/
+func (p *picker) preview() []string {
+	var repo = p.selected()
+	if repo == nil {
+		return []string{"no repositories matched"}
+	}
+	var lines = []string{
+		fmt.Sprintf("ID:      %s", repo.ID),
+		fmt.Sprintf("Groups:  %s", strings.Join(p.db.FindRelationsOfRepository(repo.ID), ", ")),
+		fmt.Sprintf("Path:    %s", repo.Path),
+	}
+	for i, remote := range repo.Remotes {
+		var label = "         "
+		if i == 0 {
+			label = "Remotes: "
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s", label, remote.Name, remote.URL))
+	}
+	return append(lines, fmt.Sprintf("Status:  %s", p.status(repo)))
+}
+
+/*
+render writes the picker to the given writer within the given size of the terminal.
+The lines are separated by CRLF, since the terminal is in the raw mode.
+*/
+func (p *picker) render(w io.Writer, width, height int) {
+	var preview = p.preview()
+	var listHeight = rrh.MaxInt(1, height-len(preview)-3)
+	var offset = rrh.MaxInt(0, p.cursor-listHeight+1)
+	var lines = []string{
+		"> " + string(p.query),
+		fmt.Sprintf("  %d/%d", len(p.matched), len(p.db.Repositories)),
+	}
+	for i := offset; i < len(p.matched) && i < offset+listHeight; i++ {
+		var marker = "  "
+		if i == p.cursor {
+			marker = "> "
+		}
+		var repo = p.matched[i].Repository
+		lines = append(lines, fmt.Sprintf("%s%s    %s", marker, repo.ID, repo.Path))
+	}
+	for i := len(lines); i < listHeight+2; i++ {
+		lines = append(lines, "")
+	}
+	lines = append(lines, strings.Repeat("-", rrh.MaxInt(1, width)))
+	lines = append(lines, preview...)
+
+	io.WriteString(w, "\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i > 0 {
+			io.WriteString(w, "\r\n")
+		}
+		io.WriteString(w, truncate(line, width))
+	}
+	io.WriteString(w, fmt.Sprintf("\x1b[1;%dH", len(p.query)+3))
+}
+
+func truncate(line string, width int) string {
+	var runes = []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	return string(runes[:width])
+}
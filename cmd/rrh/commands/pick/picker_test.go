@@ -0,0 +1,83 @@
+package pick
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tamada/rrh"
+)
+
+func TestPickerHandle(t *testing.T) {
+	var testcases = []struct {
+		keys       []string
+		wontAction action
+		wontQuery  string
+		wontID     string
+	}{
+		{[]string{}, actionNone, "", "repo1"},
+		{[]string{"\x1b[B"}, actionNone, "", "repo2"},
+		{[]string{"\x0e", "\x0e", "\x0e"}, actionNone, "", "repo2"},
+		{[]string{"\x0e", "\x1b[A", "\x10"}, actionNone, "", "repo1"},
+		{[]string{"r", "p", "2"}, actionNone, "rp2", "repo2"},
+		{[]string{"rp2", "\x7f", "\x7f"}, actionNone, "r", "repo1"},
+		{[]string{"rp2", "\x15"}, actionNone, "", "repo1"},
+		{[]string{"group3", "\r"}, actionSelect, "group3", "repo2"},
+		{[]string{"unknown", "\r"}, actionNone, "unknown", ""},
+		{[]string{"rp", "\x1b"}, actionCancel, "rp", "repo1"},
+		{[]string{"\x03"}, actionCancel, "", "repo1"},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			var p = newPicker(db, "", time.Now())
+			var got = actionNone
+			for _, key := range tc.keys {
+				got = p.handle([]byte(key))
+			}
+			if got != tc.wontAction {
+				t.Errorf("%v: action did not match, wont: %v, got: %v", tc.keys, tc.wontAction, got)
+			}
+			if string(p.query) != tc.wontQuery {
+				t.Errorf("%v: query did not match, wont: %s, got: %s", tc.keys, tc.wontQuery, string(p.query))
+			}
+			var gotID = ""
+			if repo := p.selected(); repo != nil {
+				gotID = repo.ID
+			}
+			if gotID != tc.wontID {
+				t.Errorf("%v: selected repository did not match, wont: %s, got: %s", tc.keys, tc.wontID, gotID)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestPickerRender(t *testing.T) {
+	var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		var p = newPicker(db, "rp2", time.Now())
+		var out = bytes.NewBuffer([]byte{})
+		p.render(out, 80, 24)
+		var lines = strings.Split(out.String(), "\r\n")
+		var wonts = []string{"> rp2", "  1/2", "> repo2", "ID:      repo2", "Groups:  group3", "Remotes: origin git@github.com:example/repo2.git", "Status:  unknown"}
+		for _, wont := range wonts {
+			if !containsPrefix(lines, wont) {
+				t.Errorf("rendered picker should contain %s, got: %v", wont, lines)
+			}
+		}
+		if len(lines) > 24 {
+			t.Errorf("rendered picker should fit in the terminal, got %d lines", len(lines))
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func containsPrefix(lines []string, prefix string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimPrefix(line, "\x1b[H\x1b[2J"), prefix) {
+			return true
+		}
+	}
+	return false
+}
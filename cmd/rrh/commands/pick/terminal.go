@@ -0,0 +1,29 @@
+package pick
+
+import "os"
+
+/*
+terminal represents the controlling terminal in the raw mode.
+The picker uses the controlling terminal instead of the standard input/output,
+so that the result can be captured by the command substitution of the shell (e.g., $(rrh pick)).
+*/
+type terminal struct {
+	file  *os.File
+	state interface{}
+}
+
+func (t *terminal) Read(data []byte) (int, error) {
+	return t.file.Read(data)
+}
+
+func (t *terminal) Write(data []byte) (int, error) {
+	return t.file.Write(data)
+}
+
+/*
+Close restores the state of the terminal, and closes it.
+*/
+func (t *terminal) Close() error {
+	restore(t)
+	return t.file.Close()
+}
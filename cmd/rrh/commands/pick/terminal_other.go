@@ -0,0 +1,19 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package pick
+
+import (
+	"errors"
+	"runtime"
+)
+
+func openTerminal() (*terminal, error) {
+	return nil, errors.New(runtime.GOOS + ": pick is not supported on this platform")
+}
+
+func restore(t *terminal) {
+}
+
+func (t *terminal) Size() (int, int) {
+	return 80, 24
+}
@@ -0,0 +1,55 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package pick
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+/*
+openTerminal opens the controlling terminal, and makes it raw mode.
+*/
+func openTerminal() (*terminal, error) {
+	var file, err = os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	var fd = int(file.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	var raw = *termios
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &terminal{file: file, state: termios}, nil
+}
+
+func restore(t *terminal) {
+	if termios, ok := t.state.(*unix.Termios); ok {
+		unix.IoctlSetTermios(int(t.file.Fd()), ioctlSetTermios, termios)
+	}
+}
+
+/*
+Size returns the width and the height of the terminal.
+*/
+func (t *terminal) Size() (int, int) {
+	var ws, err = unix.IoctlGetWinsize(int(t.file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 80, 24
+	}
+	return int(ws.Col), int(ws.Row)
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/dustin/go-humanize/english"
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/relocate"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
 )
 
 type pruneOptions struct {
-	dryRunFlag bool
+	dryRunFlag   bool
+	relocateFlag bool
 }
 
 var pruneOpts = &pruneOptions{}
@@ -32,6 +34,7 @@ func New() *cobra.Command {
 	}
 	flags := pruneCommand.Flags()
 	flags.BoolVarP(&pruneOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	flags.BoolVarP(&pruneOpts.relocateFlag, "relocate", "r", false, "relocate the moved repositories before pruning (see relocate command)")
 
 	return pruneCommand
 }
@@ -56,7 +59,15 @@ func dryRunMode() string {
 }
 
 func perform(c *cobra.Command, db *rrh.Database) error {
-	var repos = removeNotExistRepository(c, db, pruneOpts.dryRunFlag)
+	var relocated = []string{}
+	if pruneOpts.relocateFlag {
+		var err error
+		relocated, err = relocate.Perform(c, db, []string{}, []string{}, pruneOpts.dryRunFlag)
+		if err != nil {
+			return err
+		}
+	}
+	var repos = removeNotExistRepository(c, db, relocated)
 	var repos2, groups = db.Prune()
 	c.Printf("Pruned %s", english.Plural(len(groups), "group", ""))
 	c.Printf(" and %s", english.Plural(len(repos)+len(repos2), "repository", ""))
@@ -68,11 +79,21 @@ func perform(c *cobra.Command, db *rrh.Database) error {
 	return nil
 }
 
-func removeNotExistRepository(c *cobra.Command, db *rrh.Database, dryRunMode bool) []string {
+/*
+removeNotExistRepository removes the repositories whose paths do not exist.
+The relocated repositories are not removed, even if they are not relocated actually in the dry-run mode.
+The repositories not available on this host (see rrh.Repository.IsAvailableOn) are also kept,
+since their paths do not point the locations on this host.
+*/
+func removeNotExistRepository(c *cobra.Command, db *rrh.Database, relocated []string) []string {
 	var removeRepos = []string{}
+	var host = rrh.CurrentHostname(db.Config)
 	for _, repo := range db.Repositories {
+		if !repo.IsAvailableOn(host) {
+			continue
+		}
 		var _, err = os.Stat(repo.Path)
-		if os.IsNotExist(err) {
+		if os.IsNotExist(err) && !rrh.FindIn(repo.ID, relocated) {
 			removeRepos = append(removeRepos, repo.ID)
 		}
 	}
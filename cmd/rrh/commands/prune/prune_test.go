@@ -1,10 +1,16 @@
 package prune
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
 )
 
 type groupExistChecker struct {
@@ -42,6 +48,47 @@ func TestPrune(t *testing.T) {
 	defer os.Remove(dbFile)
 }
 
+func TestPruneWithRelocate(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-prune")
+	defer os.RemoveAll(dir)
+	var newPath = filepath.Join(dir, "moved")
+	var repo, _ = git.PlainInit(newPath, false)
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/example/moved.git"}})
+	os.Setenv(rrh.WorkspaceRoots, "tmp="+dir)
+	defer os.Unsetenv(rrh.WorkspaceRoots)
+
+	var testcases = []struct {
+		args       []string
+		wontOutput string
+	}{
+		{[]string{"--relocate"}, "Pruned 2 groups and 2 repositories"},
+		{[]string{"--relocate", "--dry-run"}, "Pruned 2 groups and 2 repositories (dry-run mode)"},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("moved", filepath.Join(dir, "old"), "", []*rrh.Remote{{Name: "origin", URL: "https://github.com/example/moved"}})
+			db.Relate("group1", "moved")
+			db.StoreAndClose()
+
+			var out = bytes.NewBuffer([]byte{})
+			var prune = New()
+			prune.SetOut(out)
+			prune.SetArgs(tc.args)
+			if err := prune.Execute(); err != nil {
+				t.Errorf("%v: prune failed: %s", tc.args, err.Error())
+			}
+			if !strings.Contains(out.String(), tc.wontOutput) || !strings.Contains(out.String(), "moved: relocated") {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.wontOutput, out.String())
+			}
+			var db2, _ = rrh.Open(cfg)
+			if !db2.HasRelation("group1", "moved") {
+				t.Errorf("%v: relocated repository should not be pruned", tc.args)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
 func TestPruneCommandRunFailedByBrokenDBFile(t *testing.T) {
 	os.Setenv(rrh.DatabasePath, "../../../../testdata/broken.json")
 	var prune = New()
@@ -85,3 +132,34 @@ func ExamplePruneCommand_Run_VerboseMode() {
 	defer os.Remove(dbFile)
 	// Output: Pruned 3 groups and 2 repositories
 }
+
+func TestPruneKeepsUnavailableRepositories(t *testing.T) {
+	os.Setenv(rrh.Hostname, "this-host")
+	defer os.Unsetenv(rrh.Hostname)
+	var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+		db.CreateRepository("unknown-root", "tamada/unknown", "", []*rrh.Remote{})
+		var unknown = db.FindRepository("unknown-root")
+		unknown.Root, unknown.Path = "undefined", "tamada/unknown"
+		db.CreateRepository("unresolved-host", "/not/exist/unresolved-host", "", []*rrh.Remote{})
+		db.FindRepository("unresolved-host").HostPaths = []*rrh.HostPath{{Host: "this-host", Root: "undefined", Path: "tamada/host"}}
+		db.Relate("group1", "unknown-root")
+		db.Relate("group1", "unresolved-host")
+		db.StoreAndClose()
+
+		var prune = New()
+		prune.SetOut(ioutil.Discard)
+		if err := prune.Execute(); err != nil {
+			t.Errorf("prune failed: %s", err.Error())
+		}
+		var db2, _ = rrh.Open(cfg)
+		for _, id := range []string{"unknown-root", "unresolved-host"} {
+			if !db2.HasRepository(id) {
+				t.Errorf("%s: repository not available on this host should not be pruned", id)
+			}
+		}
+		if db2.HasRepository("repo1") {
+			t.Errorf("repo1: not existing repository should be pruned")
+		}
+	})
+	defer os.Remove(dbFile)
+}
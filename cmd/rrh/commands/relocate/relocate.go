@@ -0,0 +1,123 @@
+package relocate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+type relocateOptions struct {
+	dryRunFlag bool
+	roots      []string
+}
+
+var relocateOpts = &relocateOptions{}
+
+func New() *cobra.Command {
+	relocateCommand := &cobra.Command{
+		Use:   "relocate [REPOSITORY_IDs...]",
+		Short: "relocate the moved repositories by searching the workspace roots",
+		Long: `relocate the repositories whose paths do not exist.
+The git repositories having the same remotes are searched in the workspace roots (RRH_WORKSPACE_ROOTS)
+and the directories given by --root option, and the path of the repository is updated to the found one.
+If no repositories are given, all of the missing repositories are the targets.`,
+		ValidArgsFunction: utils.CompleteRepositories,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	flags := relocateCommand.Flags()
+	flags.BoolVarP(&relocateOpts.dryRunFlag, "dry-run", "D", false, "dry-run mode")
+	flags.StringSliceVarP(&relocateOpts.roots, "root", "r", []string{}, "specify the additional directories for searching the repositories")
+
+	return relocateCommand
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	for _, arg := range args {
+		if !db.HasRepository(arg) {
+			return fmt.Errorf("%s: repository not found", arg)
+		}
+	}
+	var relocated, err = Perform(c, db, args, relocateOpts.roots, relocateOpts.dryRunFlag)
+	if err != nil {
+		return err
+	}
+	if len(relocated) > 0 && !relocateOpts.dryRunFlag {
+		return db.StoreAndClose()
+	}
+	return nil
+}
+
+/*
+SearchDirs returns the directories for searching the repositories,
+that is, the workspace roots and the given directories.
+*/
+func SearchDirs(config *rrh.Config, dirs []string) ([]string, error) {
+	var results = []string{}
+	for _, root := range config.WorkspaceRoots() {
+		results = append(results, root.Path)
+	}
+	for _, dir := range dirs {
+		var absPath, err = filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, absPath)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no directories for searching the repositories, set %s or specify --root option", rrh.WorkspaceRoots)
+	}
+	return results, nil
+}
+
+/*
+Perform relocates the missing repositories of the given ids, and prints the results.
+This function returns the ids of the relocated repositories.
+The database is not stored in this function.
+*/
+func Perform(c *cobra.Command, db *rrh.Database, repoIDs []string, dirs []string, dryRunFlag bool) ([]string, error) {
+	var searchDirs, err = SearchDirs(db.Config, dirs)
+	if err != nil {
+		return nil, err
+	}
+	relocations, err := db.FindRelocations(repoIDs, searchDirs)
+	if err != nil {
+		return nil, err
+	}
+	var relocated = []string{}
+	for _, relocation := range relocations {
+		if !relocation.IsRelocatable() {
+			printNotRelocated(c, db, relocation)
+			continue
+		}
+		if !dryRunFlag {
+			if err := db.Relocate(relocation.RepositoryID, relocation.NewPath); err != nil {
+				return relocated, err
+			}
+		}
+		relocated = append(relocated, relocation.RepositoryID)
+		c.Printf("%s: relocated %s -> %s%s\n", db.Config.Decorator.RepositoryID(relocation.RepositoryID), relocation.OldPath, relocation.NewPath, dryRunMode(dryRunFlag))
+	}
+	return relocated, nil
+}
+
+func printNotRelocated(c *cobra.Command, db *rrh.Database, relocation *rrh.Relocation) {
+	var id = db.Config.Decorator.RepositoryID(relocation.RepositoryID)
+	if len(relocation.Candidates) == 0 {
+		c.Printf("%s: new location not found\n", id)
+		return
+	}
+	c.Printf("%s: multiple candidates found (%s)\n", id, strings.Join(relocation.Candidates, ", "))
+}
+
+func dryRunMode(dryRunFlag bool) string {
+	if dryRunFlag {
+		return " (dry-run mode)"
+	}
+	return ""
+}
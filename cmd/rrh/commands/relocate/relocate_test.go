@@ -0,0 +1,61 @@
+package relocate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+)
+
+func TestRelocate(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-relocate")
+	defer os.RemoveAll(dir)
+	var newPath = filepath.Join(dir, "moved", "lost")
+	var repo, _ = git.PlainInit(newPath, false)
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/example/lost.git"}})
+
+	var testcases = []struct {
+		args       []string
+		hasError   bool
+		wontOutput string
+		wontPath   string
+	}{
+		{[]string{"--root", dir, "lost"}, false, "lost: relocated", newPath},
+		{[]string{"--root", dir, "--dry-run"}, false, "(dry-run mode)", filepath.Join(dir, "lost")},
+		{[]string{"lost"}, true, "", filepath.Join(dir, "lost")},
+		{[]string{"--root", dir, "unknown"}, true, "", filepath.Join(dir, "lost")},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("lost", filepath.Join(dir, "lost"), "", []*rrh.Remote{{Name: "origin", URL: "git@github.com:example/lost.git"}})
+			db.Relate("group1", "lost")
+			db.StoreAndClose()
+
+			var out = bytes.NewBuffer([]byte{})
+			var cmd = New()
+			cmd.SetOut(out)
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			if !strings.Contains(out.String(), tc.wontOutput) {
+				t.Errorf("%v: output did not match, wont: %s, got: %s", tc.args, tc.wontOutput, out.String())
+			}
+			var db2, _ = rrh.Open(cfg)
+			if path := db2.FindRepository("lost").Path; path != tc.wontPath {
+				t.Errorf("%v: path did not match, wont: %s, got: %s", tc.args, tc.wontPath, path)
+			}
+			if !db2.HasRelation("group1", "lost") {
+				t.Errorf("%v: relocated repository should keep its groups", tc.args)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
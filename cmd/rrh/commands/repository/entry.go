@@ -16,7 +16,11 @@ const (
 	remotes                = 8
 	groups                 = 16
 	groupCount             = 32
-	repositoryAll          = repositoryId | repositoryDesc | repositoryPath | remotes | groups
+	tags                   = 64
+	addedAt                = 128
+	updatedAt              = 256
+	lastAccessed           = 512
+	repositoryAll          = repositoryId | repositoryDesc | repositoryPath | remotes | groups | tags | addedAt | updatedAt | lastAccessed
 )
 
 func (re Entries) StringArray() []string {
@@ -37,9 +41,21 @@ func (re Entries) StringArray() []string {
 	if re.IsGroup() {
 		results = append(results, "groups")
 	}
-	if re.IsCount() {
+	if re.IsGroupCount() {
 		results = append(results, "group count")
 	}
+	if re.IsTags() {
+		results = append(results, "tags")
+	}
+	if re.IsAddedAt() {
+		results = append(results, "added at")
+	}
+	if re.IsUpdatedAt() {
+		results = append(results, "updated at")
+	}
+	if re.IsLastAccessed() {
+		results = append(results, "last accessed")
+	}
 	return results
 }
 
@@ -58,9 +74,21 @@ func (re Entries) IsRemotes() bool {
 func (re Entries) IsGroup() bool {
 	return re&groups == groups
 }
-func (re Entries) IsCount() bool {
+func (re Entries) IsGroupCount() bool {
 	return re&groupCount == groupCount
 }
+func (re Entries) IsTags() bool {
+	return re&tags == tags
+}
+func (re Entries) IsAddedAt() bool {
+	return re&addedAt == addedAt
+}
+func (re Entries) IsUpdatedAt() bool {
+	return re&updatedAt == updatedAt
+}
+func (re Entries) IsLastAccessed() bool {
+	return re&lastAccessed == lastAccessed
+}
 
 func NewEntries(entries []string) (Entries, error) {
 	var result Entries = 0
@@ -70,7 +98,7 @@ func NewEntries(entries []string) (Entries, error) {
 			result = result | repositoryAll
 		case "id":
 			result = result | repositoryId
-		case "count":
+		case "group-count", "count":
 			result = result | groupCount
 		case "desc":
 			result = result | repositoryDesc
@@ -80,14 +108,27 @@ func NewEntries(entries []string) (Entries, error) {
 			result = result | groups
 		case "remote":
 			result = result | remotes
+		case "tags":
+			result = result | tags
+		case "added-at":
+			result = result | addedAt
+		case "updated-at":
+			result = result | updatedAt
+		case "last-accessed":
+			result = result | lastAccessed
 		default:
-			return 0, fmt.Errorf("%s: invalid entry, availables are: all, id, desc, path, remote, group, and count", entry)
+			return 0, fmt.Errorf("%s: invalid entry, availables are: %s", entry, strings.Join(availableEntries, ", "))
 		}
 	}
 	return result, nil
 }
 
+/*
+availableEntries is the entries of repository list command.
+The count is the alias of the group-count, for the compatibility with the previous versions.
+*/
+var availableEntries = []string{"all", "id", "desc", "path", "remote", "group", "group-count", "count", "tags", "added-at", "updated-at", "last-accessed"}
+
 func ValidateEntries(entries []string) error {
-	availables := []string{"all", "id", "desc", "path", "remote", "group", "count"}
-	return utils.ValidateValues(entries, availables)
+	return utils.ValidateValues(entries, availableEntries)
 }
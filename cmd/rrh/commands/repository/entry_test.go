@@ -12,12 +12,15 @@ func TestRepositoryEntriesTest(t *testing.T) {
 		iserror bool
 		array   []string
 	}{
-		{[]string{"all"}, false, []string{"id", "description", "path", "remote name", "remote url", "groups"}},
+		{[]string{"all"}, false, []string{"id", "description", "path", "remote name", "remote url", "groups", "tags", "added at", "updated at", "last accessed"}},
 		{[]string{"id", "desc"}, false, []string{"id", "description"}},
 		{[]string{"hoge"}, true, []string{}},
 		{[]string{"hoge", "fuga"}, true, []string{}},
-		{[]string{"id", "desc", "path", "remote", "group", "count"}, false, []string{"id", "description", "path", "remote name", "remote url", "groups", "group count"}},
+		{[]string{"id", "desc", "path", "remote", "group", "group-count"}, false, []string{"id", "description", "path", "remote name", "remote url", "groups", "group count"}},
+		{[]string{"group-count"}, false, []string{"group count"}},
 		{[]string{"count"}, false, []string{"group count"}},
+		{[]string{"tags"}, false, []string{"tags"}},
+		{[]string{"added-at", "last-accessed"}, false, []string{"added at", "last accessed"}},
 	}
 	for _, td := range testdata {
 		err := ValidateEntries(td.args)
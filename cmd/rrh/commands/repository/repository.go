@@ -26,6 +26,9 @@ func registerSubCommand(c *cobra.Command) {
 	c.AddCommand(newInfoCommand())
 	c.AddCommand(newOfCommand())
 	c.AddCommand(newUpdateCommand())
+	c.AddCommand(newUpdateRemotesCommand())
+	c.AddCommand(newTagCommand())
+	c.AddCommand(newUntagCommand())
 }
 
 func perform(c *cobra.Command, args []string, db *rrh.Database) error {
@@ -14,9 +14,10 @@ import (
 
 func newInfoCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "info",
-		Short: "show repository information",
-		Args:  validateArgs,
+		Use:               "info",
+		Short:             "show repository information",
+		Args:              validateArgs,
+		ValidArgsFunction: utils.CompleteRepositories,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performInfo)
 		},
@@ -78,6 +79,14 @@ func printRepository(c *cobra.Command, repo *rrh.Repository, e Entries, db *rrh.
 	}
 	if e.IsPath() {
 		c.Printf("Path: %s\n", repo.Path)
+		for _, hp := range repo.HostPaths {
+			c.Printf("    %s: %s\n", hp.Host, hp.Path)
+		}
+	}
+	if e.IsTags() {
+		if len(repo.Tags) > 0 {
+			c.Printf("%s: %s\n", english.PluralWord(len(repo.Tags), "Tag", "Tags"), strings.Join(repo.TagStrings(), ", "))
+		}
 	}
 	if e.IsRemotes() {
 		if len(repo.Remotes) > 0 {
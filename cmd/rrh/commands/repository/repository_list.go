@@ -2,33 +2,63 @@ package repository
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
 	"github.com/tamada/rrh/common"
+	"github.com/tamada/rrh/filter"
+	"github.com/tamada/rrh/formatter"
+	"github.com/tamada/rrh/selector"
 )
 
 type listOptions struct {
-	entries []string
+	entries        []string
+	filter         string
+	compiledFilter *filter.Filter
+	format         string
+	header         bool
+	sortKey        string
+	reverse        bool
 }
 
 var listOpts = &listOptions{}
 
 func newListCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list [GROUPs...]",
+		Use:   "list [GROUP_SELECTORs...]",
 		Short: "list repositories",
 		Args: func(c *cobra.Command, args []string) error {
+			f, err := filter.Compile(listOpts.filter)
+			if err != nil {
+				return err
+			}
+			listOpts.compiledFilter = f
+			if err := formatter.Validate(listOpts.format, formatter.Formats); err != nil {
+				return err
+			}
+			if listOpts.sortKey != "" {
+				if err := utils.ValidateValue(listOpts.sortKey, rrh.RepositorySortKeys); err != nil {
+					return err
+				}
+			}
 			return ValidateEntries(listOpts.entries)
 		},
+		ValidArgsFunction: utils.CompleteGroups,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performList)
 		},
 	}
 	flags := cmd.Flags()
-	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"id", "group", "path"}, "print the repository information")
+	flags.StringSliceVarP(&listOpts.entries, "entry", "e", []string{"id", "group", "path"}, "specifies the printing entries.\navailables: all, id, desc, path, remote, group, group-count (count), tags, added-at, updated-at, and last-accessed")
+	flags.StringVarP(&listOpts.filter, "filter", "F", "", "specify the filter expression of the printing repositories (e.g., tag(\"lang\", \"go\") && !dirty)")
+	flags.StringVarP(&listOpts.format, "format", "f", "default", "specifies the output format. Available values: default, table, csv, markdown, json, ndjson, yaml, and template=TEMPLATE (e.g., template='{{.ID}}\\t{{.Path}}')")
+	flags.BoolVarP(&listOpts.header, "no-header", "H", false, "print without headers")
+	flags.StringVarP(&listOpts.sortKey, "sort", "s", "", "specifies the sort key of the repositories.\navailables: id, path, group, added-at, updated-at, last-accessed, last-commit, and size (the times are sorted in the newest first order, and the size is in the largest first order)")
+	flags.BoolVarP(&listOpts.reverse, "reverse", "r", false, "reverses the sorting order")
+	cmd.RegisterFlagCompletionFunc("format", utils.CompleteValues(formatter.Formats...))
+	cmd.RegisterFlagCompletionFunc("sort", utils.CompleteValues(rrh.RepositorySortKeys...))
 	return cmd
 }
 
@@ -37,53 +67,143 @@ func performList(c *cobra.Command, args []string, db *rrh.Database) error {
 	if err != nil {
 		return err
 	}
+	var f = listOpts.compiledFilter
 	if len(args) == 0 {
-		return executeList(c, db, db.Groups, entries)
-	}
-	el := common.NewErrorList()
-	groups, errs := findGroups(args, db)
-	el = el.Append(errs)
-	err2 := executeList(c, db, groups, entries)
-	el = el.Append(err2)
-	return el.NilOrThis()
+		return executeList(c, db, db.Groups, entries, f, nil)
+	}
+	selected, err := selector.Resolve(db, args)
+	if err != nil {
+		return err
+	}
+	return executeList(c, db, findGroups(selected, db), entries, f, selected)
 }
 
-func findGroups(args []string, db *rrh.Database) ([]*rrh.Group, error) {
+func findGroups(selected *selector.Result, db *rrh.Database) []*rrh.Group {
+	if selected.IsAllGroups() {
+		return db.Groups
+	}
 	results := []*rrh.Group{}
-	errs := common.ErrorList{}
-	for _, arg := range args {
-		group := db.FindGroup(arg)
-		if group != nil {
+	for _, name := range db.ExpandGroupNames(selected.Groups) {
+		if group := db.FindGroup(name); group != nil {
 			results = append(results, group)
-		} else {
-			errs = errs.Append(fmt.Errorf("%s: group not found", arg))
 		}
 	}
-	return results, errs.NilOrThis()
+	return results
 }
 
-func executeList(c *cobra.Command, db *rrh.Database, groups []*rrh.Group, li Entries) error {
+/*
+listRow represents the repository printed in a row of the repository list, with its group.
+*/
+type listRow struct {
+	group *rrh.Group
+	repo  *rrh.Repository
+}
+
+/*
+sortRows sorts the rows by the given key.
+The group key sorts the rows by the group names, and the other keys sort them by the repositories.
+*/
+func sortRows(db *rrh.Database, rows []*listRow, key string, reverse bool) error {
+	if key == "" {
+		return nil
+	}
+	sorter, err := rrh.NewRepositorySorter(db, key, reverse)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if sorter.IsGroupKey() {
+			return sorter.LessGroupNames(rows[i].group.Name, rows[j].group.Name)
+		}
+		return sorter.Less(rows[i].repo, rows[j].repo)
+	})
+	return nil
+}
+
+func executeList(c *cobra.Command, db *rrh.Database, groups []*rrh.Group, li Entries, f *filter.Filter, selected *selector.Result) error {
 	err := common.NewErrorList()
-	results := [][]string{}
+	rows := []*listRow{}
 	for _, group := range groups {
 		repos, errs := findRepositories(group, db)
 		for _, repo := range repos {
-			r := formatRepository(c, group, repo, li)
-			results = append(results, r)
+			if selected != nil && !selected.Contains(repo.ID) {
+				continue
+			}
+			if matched, e := f.Match(db, repo); !matched {
+				err = err.Append(e)
+				continue
+			}
+			rows = append(rows, &listRow{group: group, repo: repo})
 		}
 		err.Append(errs)
 	}
-	printAll(c, results, li)
+	if e := sortRows(db, rows, listOpts.sortKey, listOpts.reverse); e != nil {
+		return e
+	}
+	combined := isCombinedName(listOpts.format)
+	table := formatter.NewTable(listColumns(li, combined)...)
+	for _, row := range rows {
+		table.Append(formatRepository(db, row.group, row.repo, li, combined)...)
+	}
+	if e := printAll(c, table); e != nil {
+		return e
+	}
 	return err.NilOrThis()
 }
 
-func printAll(c *cobra.Command, r [][]string, li Entries) {
-	table := tablewriter.NewWriter(c.OutOrStdout())
-	table.SetBorder(false)
-	table.SetNoWhiteSpace(true)
-	table.SetTablePadding("    ")
-	table.AppendBulk(r)
-	table.Render()
+func printAll(c *cobra.Command, table *formatter.Table) error {
+	f, err := formatter.New(listOpts.format, !listOpts.header)
+	if err != nil {
+		return err
+	}
+	return f.Format(c.OutOrStdout(), table)
+}
+
+/*
+isCombinedName returns true if the group name and the repository id are printed in the one column (GROUP/ID).
+The default format combines them, and the other formats print them in the separated columns.
+*/
+func isCombinedName(format string) bool {
+	name, _ := formatter.Parse(format)
+	return name == "default"
+}
+
+func listColumns(li Entries, combined bool) []*formatter.Column {
+	columns := []*formatter.Column{}
+	if combined && (li.IsGroup() || li.IsId()) {
+		columns = append(columns, formatter.NewColumn("Name", "name", "name"))
+	}
+	if !combined && li.IsGroup() {
+		columns = append(columns, formatter.NewColumn("Group", "group", "group"))
+	}
+	if !combined && li.IsId() {
+		columns = append(columns, formatter.NewColumn("ID", "id", "id"))
+	}
+	if li.IsPath() {
+		columns = append(columns, formatter.NewColumn("Path", "path", "path"))
+	}
+	if li.IsDesc() {
+		columns = append(columns, formatter.NewColumn("Description", "description", "description"))
+	}
+	if li.IsRemotes() {
+		columns = append(columns, formatter.NewColumn("Remotes", "remotes", "remotes"))
+	}
+	if li.IsGroupCount() {
+		columns = append(columns, formatter.NewColumn("GroupCount", "group-count", "group count"))
+	}
+	if li.IsTags() {
+		columns = append(columns, formatter.NewColumn("Tags", "tags", "tags"))
+	}
+	if li.IsAddedAt() {
+		columns = append(columns, formatter.NewColumn("AddedAt", "added-at", "added at"))
+	}
+	if li.IsUpdatedAt() {
+		columns = append(columns, formatter.NewColumn("UpdatedAt", "updated-at", "updated at"))
+	}
+	if li.IsLastAccessed() {
+		columns = append(columns, formatter.NewColumn("LastAccessed", "last-accessed", "last accessed"))
+	}
+	return columns
 }
 
 func findRepositories(group *rrh.Group, db *rrh.Database) ([]*rrh.Repository, common.ErrorList) {
@@ -101,17 +221,50 @@ func findRepositories(group *rrh.Group, db *rrh.Database) ([]*rrh.Repository, co
 	return results, errs
 }
 
-func formatRepository(c *cobra.Command, group *rrh.Group, repo *rrh.Repository, li Entries) []string {
-	results := []string{}
-	if li.IsGroup() || li.IsId() {
+func formatRepository(db *rrh.Database, group *rrh.Group, repo *rrh.Repository, li Entries, combined bool) []interface{} {
+	config := db.Config
+	results := []interface{}{}
+	if combined && (li.IsGroup() || li.IsId()) {
 		results = append(results, formatRepositoryName(group, repo, li))
 	}
+	if !combined && li.IsGroup() {
+		results = append(results, group.Name)
+	}
+	if !combined && li.IsId() {
+		results = append(results, repo.ID)
+	}
 	if li.IsPath() {
 		results = append(results, repo.Path)
 	}
 	if li.IsDesc() {
 		results = append(results, repo.Description)
 	}
+	if li.IsRemotes() {
+		results = append(results, remoteURLs(repo))
+	}
+	if li.IsGroupCount() {
+		results = append(results, len(db.FindRelationsOfRepository(repo.ID)))
+	}
+	if li.IsTags() {
+		results = append(results, repo.TagStrings())
+	}
+	if li.IsAddedAt() {
+		results = append(results, rrh.StrftimeOf(repo.AddedAt, config))
+	}
+	if li.IsUpdatedAt() {
+		results = append(results, rrh.StrftimeOf(repo.UpdatedAt, config))
+	}
+	if li.IsLastAccessed() {
+		results = append(results, rrh.StrftimeOf(repo.LastAccessed, config))
+	}
+	return results
+}
+
+func remoteURLs(repo *rrh.Repository) []string {
+	results := []string{}
+	for _, remote := range repo.Remotes {
+		results = append(results, remote.URL)
+	}
 	return results
 }
 
@@ -120,7 +273,7 @@ func formatRepositoryName(group *rrh.Group, repo *rrh.Repository, li Entries) st
 		return fmt.Sprintf("%s/%s", group.Name, repo.ID)
 	} else if !li.IsGroup() && li.IsId() {
 		return repo.ID
-	} else if li.IsCount() && !li.IsId() {
+	} else if li.IsGroup() && !li.IsId() {
 		return group.Name
 	}
 	return ""
@@ -12,9 +12,10 @@ import (
 
 func newOfCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "of",
-		Short: "show repository list of the given groups. This is an alias of \"rrh list\" command",
-		Args:  cobra.MinimumNArgs(1),
+		Use:               "of",
+		Short:             "show repository list of the given groups. This is an alias of \"rrh list\" command",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: utils.CompleteGroups,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performOf)
 		},
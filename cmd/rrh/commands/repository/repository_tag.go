@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/common"
+)
+
+type tagOptions struct {
+	dryRunMode bool
+}
+
+var tagOpts = &tagOptions{}
+
+func newTagCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "tag <REPOSITORY_ID> <TAGs...>",
+		Short:             "put the tags (KEY or KEY=VALUE) to the given repository",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: utils.CompleteRepository,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, performTag)
+		},
+	}
+	flags := cmd.Flags()
+	flags.BoolVarP(&tagOpts.dryRunMode, "dry-run", "D", false, "dry-run mode")
+	return cmd
+}
+
+func newUntagCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "untag <REPOSITORY_ID> <TAG_KEYs...>",
+		Short:             "remove the tags of the given keys from the given repository",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: utils.CompleteRepository,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, performUntag)
+		},
+	}
+	flags := cmd.Flags()
+	flags.BoolVarP(&tagOpts.dryRunMode, "dry-run", "D", false, "dry-run mode")
+	return cmd
+}
+
+func parseTags(args []string) ([]*rrh.Tag, error) {
+	el := common.NewErrorList()
+	tags := []*rrh.Tag{}
+	for _, arg := range args {
+		tag, err := rrh.ParseTag(arg)
+		if err != nil {
+			el = el.Append(err)
+		} else {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, el.NilOrThis()
+}
+
+func performTag(c *cobra.Command, args []string, db *rrh.Database) error {
+	tags, err := parseTags(args[1:])
+	if err != nil {
+		return err
+	}
+	if err := db.TagRepository(args[0], tags); err != nil {
+		return err
+	}
+	return storeTags(c, db, args[0])
+}
+
+func performUntag(c *cobra.Command, args []string, db *rrh.Database) error {
+	if err := db.UntagRepository(args[0], args[1:]); err != nil {
+		return err
+	}
+	return storeTags(c, db, args[0])
+}
+
+func storeTags(c *cobra.Command, db *rrh.Database, repoID string) error {
+	repo := db.FindRepository(repoID)
+	c.Printf("%s: %s", db.Config.Decorator.RepositoryID(repo.ID), strings.Join(repo.TagStrings(), ", "))
+	if tagOpts.dryRunMode {
+		c.Println(" (dry-run mode)")
+		return nil
+	}
+	c.Println()
+	return db.StoreAndClose()
+}
@@ -2,12 +2,15 @@ package repository
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
 )
 
 func TestRepository(t *testing.T) {
@@ -23,6 +26,9 @@ func TestRepository(t *testing.T) {
 		{[]string{"list", "repo2"}, true, "", true},
 		{[]string{"list", "-e", "id,path", "group3"}, false, "repo2    path2", false},
 		{[]string{"list", "--entry", "group,id", "group1"}, false, "group1/repo1", false},
+		{[]string{"list", "--entry", "id,group-count"}, false, "repo1    1    +repo2    1", false},
+		{[]string{"list", "-f", "csv", "-H", "-e", "id,count"}, false, "repo1,1+repo2,1", false},
+		{[]string{"list", "-f", "json", "-e", "id,group-count", "group1"}, false, `[{"id":"repo1","group-count":1}]`, false},
 	}
 	for _, tc := range testcases {
 		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
@@ -60,6 +66,21 @@ func TestListRepository(t *testing.T) {
 		{[]string{"--entry", "path", "group1"}, false, "path1"},
 		{[]string{"--entry", "group,id", "group3"}, false, "group3/repo2"},
 		{[]string{}, false, "group1/repo1    path1    +group3/repo2    path2"},
+		{[]string{"--entry", "id", "--filter", `remote =~ "github.com"`}, false, "repo2"},
+		{[]string{"--entry", "id", "--filter", `id == "repo2"`, "group1"}, false, ""},
+		{[]string{"--filter", `remotes =~ "github.com"`}, true, ""},
+		{[]string{"--entry", "id", "group*,!group1"}, false, "repo2"},
+		{[]string{"--entry", "id", "unknown"}, true, ""},
+		{[]string{"--format", "csv", "--entry", "group,id,path"}, false, "group,id,path+group1,repo1,path1+group3,repo2,path2"},
+		{[]string{"--format", "csv", "--no-header", "--entry", "id", "group1"}, false, "repo1"},
+		{[]string{"--format", "ndjson", "--entry", "id,path"}, false, `{"id":"repo1","path":"path1"}+{"id":"repo2","path":"path2"}`},
+		{[]string{"--format", `template={{.Group}}:{{.ID}}`}, false, "group1:repo1+group3:repo2"},
+		{[]string{"--format", "unknown"}, true, ""},
+		{[]string{"--format", "template"}, true, ""},
+		{[]string{"--entry", "id", "--sort", "id", "--reverse"}, false, "repo2    +repo1"},
+		{[]string{"--entry", "group,id", "--sort", "group", "-r"}, false, "group3/repo2    +group1/repo1"},
+		{[]string{"--entry", "id,remote", "-f", "csv", "-H"}, false, "repo1,+repo2,git@github.com:example/repo2.git"},
+		{[]string{"--sort", "unknown"}, true, ""},
 	}
 	for _, tc := range testcases {
 		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
@@ -163,3 +184,91 @@ func TestUpdateRepository(t *testing.T) {
 		defer os.Remove(dbFile)
 	}
 }
+
+func TestTagAndUntagRepository(t *testing.T) {
+	var testcases = []struct {
+		args     []string
+		hasError bool
+		repoID   string
+		wontTags string
+	}{
+		{[]string{"tag", "repo1", "lang=go", "archived"}, false, "repo1", "lang=go,archived"},
+		{[]string{"tag", "repo1", "=go"}, true, "repo1", ""},
+		{[]string{"tag", "--dry-run", "repo1", "lang=go"}, false, "repo1", ""},
+		{[]string{"tag", "repo4", "lang=go"}, true, "", ""},
+		{[]string{"untag", "repo1", "lang"}, true, "repo1", ""},
+		{[]string{"tag", "repo1"}, true, "", ""},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, oldDB *rrh.Database) {
+			cmd := New()
+			cmd.SetOut(bytes.NewBuffer([]byte{}))
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if err == nil && tc.hasError || err != nil && !tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			if tc.repoID == "" {
+				return
+			}
+			var db, _ = rrh.Open(config)
+			var tags = strings.Join(db.FindRepository(tc.repoID).TagStrings(), ",")
+			if tags != tc.wontTags {
+				t.Errorf("%v: tags did not match: wont: %s, got: %s", tc.args, tc.wontTags, tags)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestUpdateRemotes(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-remotes")
+	defer os.RemoveAll(dir)
+	var r, _ = git.PlainInit(dir, false)
+	r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/example/new.git"}})
+	r.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{"https://github.com/upstream/new.git"}})
+
+	var testcases = []struct {
+		args        []string
+		hasError    bool
+		wontOutputs []string
+		wontRemotes string
+	}{
+		{[]string{"update-remotes", "tmprepo"}, false, []string{"~ origin: https://github.com/example/old.git -> https://github.com/example/new.git", "+ upstream: https://github.com/upstream/new.git", "- fork: https://github.com/fork/old.git"}, "origin,upstream"},
+		{[]string{"update-remotes", "--dry-run", "tmprepo"}, false, []string{"tmprepo (dry-run mode)"}, "origin,fork"},
+		{[]string{"update-remotes", "-g", "group4"}, false, []string{"+ upstream"}, "origin,upstream"},
+		{[]string{"update-remotes", "-g", "group1"}, true, []string{}, "origin,fork"},
+		{[]string{"update-remotes", "unknown"}, true, []string{}, "origin,fork"},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(cfg *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("tmprepo", dir, "", []*rrh.Remote{{Name: "origin", URL: "https://github.com/example/old.git"}, {Name: "fork", URL: "https://github.com/fork/old.git"}})
+			db.CreateGroup("group4", "", false)
+			db.Relate("group4", "tmprepo")
+			db.StoreAndClose()
+
+			var out = bytes.NewBuffer([]byte{})
+			cmd := New()
+			cmd.SetOut(out)
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if err == nil && tc.hasError || err != nil && !tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			for _, wont := range tc.wontOutputs {
+				if !strings.Contains(out.String(), wont) {
+					t.Errorf("%v: output did not contain %s, got: %s", tc.args, wont, out.String())
+				}
+			}
+			var db2, _ = rrh.Open(cfg)
+			var names = []string{}
+			for _, remote := range db2.FindRepository("tmprepo").Remotes {
+				names = append(names, remote.Name)
+			}
+			if strings.Join(names, ",") != tc.wontRemotes {
+				t.Errorf("%v: remotes did not match, wont: %s, got: %v", tc.args, tc.wontRemotes, names)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
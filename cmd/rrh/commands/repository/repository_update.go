@@ -10,13 +10,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tamada/rrh"
 	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/common"
 )
 
 func newUpdateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "update",
-		Args:  validateUpdate,
-		Short: "update repository information",
+		Use:               "update",
+		Args:              validateUpdate,
+		Short:             "update repository information",
+		ValidArgsFunction: utils.CompleteRepository,
 		RunE: func(c *cobra.Command, args []string) error {
 			return utils.PerformRrhCommand(c, args, performUpdate)
 		},
@@ -28,6 +30,8 @@ func newUpdateCommand() *cobra.Command {
 	flags.StringSliceVarP(&updateOpts.appendGroups, "append-group", "", []string{}, "specify the appending groups")
 	flags.StringSliceVarP(&updateOpts.groups, "group", "", []string{}, "replace the groups of the repository")
 	flags.StringVarP(&updateOpts.newDescription, "description", "", "", "specify the new repository description")
+	cmd.RegisterFlagCompletionFunc("group", utils.CompleteGroups)
+	cmd.RegisterFlagCompletionFunc("append-group", utils.CompleteGroups)
 	return cmd
 }
 
@@ -120,7 +124,12 @@ func updateRepoInfo(repo *rrh.Repository, db *rrh.Database) ([][]string, error)
 func removeAllGroups(repo *rrh.Repository, db *rrh.Database) error {
 	groups := db.FindRelationsOfRepository(repo.ID)
 	for _, group := range groups {
-		db.Unrelate(group, repo.ID)
+		if g := db.FindGroup(group); g != nil && g.IsDynamic() {
+			continue
+		}
+		if err := db.Unrelate(group, repo.ID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -137,10 +146,11 @@ func updateGroups(repo *rrh.Repository, db *rrh.Database) ([]string, []string, e
 		}
 		groups = updateOpts.groups
 	}
+	el := common.NewErrorList()
 	for _, group := range groups {
-		db.Relate(group, repo.ID)
+		el = el.Append(db.Relate(group, repo.ID))
 	}
-	return oldGroups, db.FindRelationsOfRepository(repo.ID), nil
+	return oldGroups, db.FindRelationsOfRepository(repo.ID), el.NilOrThis()
 }
 
 func checkPath(path string) (string, error) {
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/common"
+	"github.com/tamada/rrh/selector"
+)
+
+type updateRemotesOptions struct {
+	dryRunMode bool
+	groups     []string
+}
+
+var updateRemotesOpts = &updateRemotesOptions{}
+
+func newUpdateRemotesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-remotes [REPOSITORY_IDs...]",
+		Short: "update the remotes of the repositories by reading .git/config",
+		Long: `update the remotes of the repositories by reading .git/config.
+If no repositories and no groups are given, the remotes of all repositories are updated.`,
+		ValidArgsFunction: utils.CompleteRepositories,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, performUpdateRemotes)
+		},
+	}
+	flags := cmd.Flags()
+	flags.BoolVarP(&updateRemotesOpts.dryRunMode, "dry-run", "D", false, "dry-run mode")
+	flags.StringSliceVarP(&updateRemotesOpts.groups, "group", "g", []string{}, "specify the groups (the set-algebra selector) of the target repositories")
+	cmd.RegisterFlagCompletionFunc("group", utils.CompleteGroups)
+	return cmd
+}
+
+/*
+remoteDiff shows the difference of the remote.
+Old is empty if the remote is added, and New is empty if the remote is removed.
+*/
+type remoteDiff struct {
+	Name string
+	Old  string
+	New  string
+}
+
+func (diff *remoteDiff) String() string {
+	switch {
+	case diff.Old == "":
+		return fmt.Sprintf("+ %s: %s", diff.Name, diff.New)
+	case diff.New == "":
+		return fmt.Sprintf("- %s: %s", diff.Name, diff.Old)
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", diff.Name, diff.Old, diff.New)
+}
+
+func findRemote(remotes []*rrh.Remote, name string) *rrh.Remote {
+	for _, remote := range remotes {
+		if remote.Name == name {
+			return remote
+		}
+	}
+	return nil
+}
+
+func diffRemotes(oldRemotes, newRemotes []*rrh.Remote) []*remoteDiff {
+	var diffs = []*remoteDiff{}
+	for _, remote := range newRemotes {
+		var old = findRemote(oldRemotes, remote.Name)
+		if old == nil {
+			diffs = append(diffs, &remoteDiff{Name: remote.Name, New: remote.URL})
+		} else if old.URL != remote.URL {
+			diffs = append(diffs, &remoteDiff{Name: remote.Name, Old: old.URL, New: remote.URL})
+		}
+	}
+	for _, remote := range oldRemotes {
+		if findRemote(newRemotes, remote.Name) == nil {
+			diffs = append(diffs, &remoteDiff{Name: remote.Name, Old: remote.URL})
+		}
+	}
+	return diffs
+}
+
+func findUpdateRemotesTargets(args []string, db *rrh.Database) ([]string, error) {
+	if len(args) == 0 && len(updateRemotesOpts.groups) == 0 {
+		var results = []string{}
+		for _, repo := range db.Repositories {
+			results = append(results, repo.ID)
+		}
+		return results, nil
+	}
+	var el = common.NewErrorList()
+	var results = []string{}
+	for _, arg := range args {
+		if !db.HasRepository(arg) {
+			el = el.Append(fmt.Errorf("%s: repository not found", arg))
+		} else if !rrh.FindIn(arg, results) {
+			results = append(results, arg)
+		}
+	}
+	if len(updateRemotesOpts.groups) > 0 {
+		var selected, err = selector.Resolve(db, updateRemotesOpts.groups)
+		if err != nil {
+			return nil, err
+		}
+		for _, repoID := range selected.Repositories {
+			if !rrh.FindIn(repoID, results) {
+				results = append(results, repoID)
+			}
+		}
+	}
+	return results, el.NilOrThis()
+}
+
+func performUpdateRemotes(c *cobra.Command, args []string, db *rrh.Database) error {
+	var targets, err = findUpdateRemotesTargets(args, db)
+	if err != nil {
+		return err
+	}
+	var el = common.NewErrorList()
+	var updated = 0
+	for _, repoID := range targets {
+		var repo = db.FindRepository(repoID)
+		var remotes, err = rrh.FindRemotes(repo.Path)
+		if err != nil {
+			el = el.Append(fmt.Errorf("%s: %s", repoID, err.Error()))
+			continue
+		}
+		var diffs = diffRemotes(repo.Remotes, remotes)
+		if len(diffs) == 0 {
+			continue
+		}
+		printRemoteDiffs(c, db, repoID, diffs)
+		var newRepo = *repo
+		newRepo.Remotes = remotes
+		db.UpdateRepository(repoID, newRepo)
+		updated++
+	}
+	if updated > 0 && !updateRemotesOpts.dryRunMode {
+		el = el.Append(db.StoreAndClose())
+	}
+	return el.NilOrThis()
+}
+
+func printRemoteDiffs(c *cobra.Command, db *rrh.Database, repoID string, diffs []*remoteDiff) {
+	var suffix = ""
+	if updateRemotesOpts.dryRunMode {
+		suffix = " (dry-run mode)"
+	}
+	c.Printf("%s%s\n", db.Config.Decorator.RepositoryID(repoID), suffix)
+	for _, diff := range diffs {
+		c.Printf("    %s\n", diff.String())
+	}
+}
@@ -0,0 +1,97 @@
+package scan
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+)
+
+type scanOptions struct {
+	walk *rrh.WalkOption
+}
+
+var scanOpts = &scanOptions{walk: rrh.NewWalkOption()}
+
+func New() *cobra.Command {
+	scanCommand := &cobra.Command{
+		Use:   "scan [DIRs...]",
+		Short: "report the git repositories not registered in the rrh database",
+		Long: `report the git repositories not registered in the rrh database.
+The given directories are searched, and the workspace roots (RRH_WORKSPACE_ROOTS) are searched if no directories are given.
+For registering the found repositories, use "rrh add --recursive".`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	utils.AddWalkFlags(scanCommand, scanOpts.walk)
+
+	return scanCommand
+}
+
+func findSearchDirs(config *rrh.Config, args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var dirs = []string{}
+	for _, root := range config.WorkspaceRoots() {
+		dirs = append(dirs, root.Path)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no directories to scan, set %s or give the directories", rrh.WorkspaceRoots)
+	}
+	return dirs, nil
+}
+
+/*
+findUnregisteredRepositories returns the paths of the git repositories under the given directories, which are not registered in the database.
+*/
+func findUnregisteredRepositories(db *rrh.Database, dirs []string, opt *rrh.WalkOption) ([]string, error) {
+	var paths, err = opt.FindGitRepositories(dirs...)
+	if err != nil {
+		return nil, err
+	}
+	var registered = map[string]bool{}
+	for _, repo := range db.Repositories {
+		registered[filepath.Clean(repo.Path)] = true
+	}
+	var results = []string{}
+	for _, path := range paths {
+		var absPath, _ = filepath.Abs(path)
+		if !registered[filepath.Clean(absPath)] {
+			results = append(results, absPath)
+		}
+	}
+	return results, nil
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	var dirs, err = findSearchDirs(db.Config, args)
+	if err != nil {
+		return err
+	}
+	paths, err := findUnregisteredRepositories(db, dirs, scanOpts.walk)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		printRepository(c, db, path)
+	}
+	return nil
+}
+
+/*
+printRepository prints the path of the found repository with the groups assigned by the assignment rules.
+*/
+func printRepository(c *cobra.Command, db *rrh.Database, path string) {
+	var remotes, _ = rrh.FindRemotes(path)
+	var repo = &rrh.Repository{ID: filepath.Base(path), Path: path, Remotes: remotes}
+	if groups := db.FindAssignedGroups(repo); len(groups) > 0 {
+		c.Printf("%s (groups: %s)\n", path, strings.Join(groups, ", "))
+		return
+	}
+	c.Println(path)
+}
@@ -0,0 +1,63 @@
+package scan
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+	"gopkg.in/src-d/go-git.v4"
+)
+
+func TestScan(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-scan")
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"registered", "unregistered", "deep/nested/unregistered2"} {
+		git.PlainInit(filepath.Join(dir, name), false)
+	}
+
+	var testcases = []struct {
+		args     []string
+		env      string
+		hasError bool
+		wont     []string
+	}{
+		{[]string{dir}, "", false, []string{"deep/nested/unregistered2", "unregistered"}},
+		{[]string{"--max-depth", "1", dir}, "", false, []string{"unregistered"}},
+		{[]string{"--ignore", "deep"}, "tmp=" + dir, false, []string{"unregistered"}},
+		{[]string{}, "", true, []string{}},
+		{[]string{filepath.Join(dir, "not-exist")}, "", true, []string{}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			os.Setenv(rrh.WorkspaceRoots, tc.env)
+			defer os.Unsetenv(rrh.WorkspaceRoots)
+			db.CreateRepository("registered", filepath.Join(dir, "registered"), "", []*rrh.Remote{})
+			db.StoreAndClose()
+
+			var out = bytes.NewBuffer([]byte{})
+			var cmd = New()
+			cmd.SetOut(out)
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			if tc.hasError {
+				return
+			}
+			var got = []string{}
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var rel, _ = filepath.Rel(dir, line)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if strings.Join(got, ",") != strings.Join(tc.wont, ",") {
+				t.Errorf("%v: found repositories did not match, wont: %v, got: %v", tc.args, tc.wont, got)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
 )
 
 type sfgOptions struct {
@@ -15,6 +18,7 @@ type sfgOptions struct {
 	withoutCdrrh   bool
 	withoutRrhPeco bool
 	withoutRrhFzf  bool
+	hook           bool
 }
 
 var sfgOpts = &sfgOptions{}
@@ -22,44 +26,86 @@ var sfgOpts = &sfgOptions{}
 //go:embed data
 var functions embed.FS
 
+/*
+shellDirs maps the shell names to the directories in data.
+The scripts are searched in the directories in order, e.g., zsh uses the scripts for bash if the zsh version is not found.
+*/
+var shellDirs = map[string][]string{
+	"sh":      {"bash"},
+	"bash":    {"bash"},
+	"zsh":     {"zsh", "bash"},
+	"fish":    {"fish"},
+	"nu":      {"nushell"},
+	"nushell": {"nushell"},
+}
+
 func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "generate shell functions for shell",
-		Args:  validateArgs,
-		RunE:  perform,
+		Long: `generate shell functions for shell.
+Use this command in the shell profile, such as eval "$(rrh init)".
+The --hook option generates the hook, which runs "rrh hook chpwd" on changing the current directory,
+for updating the last accessed time of the registered repositories, and for registering the new repositories.
+The hook is available in bash, zsh, and fish.`,
+		Args: validateArgs,
+		RunE: perform,
 	}
 	flags := cmd.Flags()
-	flags.StringVarP(&sfgOpts.shellName, "shell", "s", "", "specify the shell name. this option is mandatory. availables are: bash, and zsh")
+	flags.StringVarP(&sfgOpts.shellName, "shell", "s", "", "specify the shell name. availables are: bash, zsh, fish, and nushell (nu).\nif this option is omitted, the shell is detected from $SHELL")
 	flags.BoolVarP(&sfgOpts.withoutCdrrh, "without-cdrrh", "", false, "not generate the cdrrh function")
 	flags.BoolVarP(&sfgOpts.withoutRrhPeco, "without-rrhpeco", "", false, "not generate the rrhpeco function")
 	flags.BoolVarP(&sfgOpts.withoutRrhFzf, "without-rrhfzf", "", false, "not generate the rrhfzf function")
+	flags.BoolVarP(&sfgOpts.hook, "hook", "", false, "generate the hook for changing the current directory")
+	cmd.RegisterFlagCompletionFunc("shell", utils.CompleteValues("bash", "zsh", "fish", "nushell"))
 	return cmd
 }
 
-func validateArgs(c *cobra.Command, args []string) error {
-	if sfgOpts.shellName == "" {
-		return errors.New("shell name option is mandatory")
+/*
+findShell returns the name of the target shell from --shell option, or $SHELL environment variable.
+*/
+func findShell() (string, error) {
+	var shell = sfgOpts.shellName
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if shell == "." || shell == string(filepath.Separator) {
+			return "", errors.New("shell could not be detected from $SHELL, specify --shell option")
+		}
 	}
-	switch strings.ToLower(sfgOpts.shellName) {
-	case "bash", "zsh":
-		return nil
-	default:
-		return fmt.Errorf("%s: unsupported shell", sfgOpts.shellName)
+	shell = strings.ToLower(shell)
+	if _, ok := shellDirs[shell]; !ok {
+		return "", fmt.Errorf("%s: unsupported shell", shell)
 	}
+	return shell, nil
+}
+
+func validateArgs(c *cobra.Command, args []string) error {
+	_, err := findShell()
+	return err
 }
 
 func perform(c *cobra.Command, args []string) error {
 	c.SilenceUsage = true
-	shell := strings.ToLower(sfgOpts.shellName)
-	switch shell {
-	case "sh", "bash", "zsh":
-		return printFunctions(c, shell, "bash")
+	shell, err := findShell()
+	if err != nil {
+		return err
+	}
+	if sfgOpts.hook && !hasScript(shellDirs[shell], "hook") {
+		return fmt.Errorf("%s: the hook is not supported", shell)
 	}
-	return fmt.Errorf("%s: unsupported shell", sfgOpts.shellName)
+	return printFunctions(c, shellDirs[shell])
 }
 
-func printFunctions(c *cobra.Command, shellName, defaultShellName string) error {
+func hasScript(dirs []string, scriptName string) bool {
+	for _, dir := range dirs {
+		if _, err := functions.Open(fmt.Sprintf("data/%s/%s", dir, scriptName)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func printFunctions(c *cobra.Command, dirs []string) error {
 	executors := []struct {
 		withoutFlag bool
 		scriptName  string
@@ -67,13 +113,11 @@ func printFunctions(c *cobra.Command, shellName, defaultShellName string) error
 		{sfgOpts.withoutCdrrh, "cdrrh"},
 		{sfgOpts.withoutRrhPeco, "rrhpeco"},
 		{sfgOpts.withoutRrhFzf, "rrhfzf"},
+		{!sfgOpts.hook, "hook"},
 	}
 	for _, executor := range executors {
 		if !executor.withoutFlag {
-			if err := printFunction(c, shellName, executor.scriptName); err == nil {
-				continue
-			}
-			if err := printFunction(c, defaultShellName, executor.scriptName); err != nil {
+			if err := printFirstFunction(c, dirs, executor.scriptName); err != nil {
 				return err
 			}
 		}
@@ -81,6 +125,16 @@ func printFunctions(c *cobra.Command, shellName, defaultShellName string) error
 	return nil
 }
 
+func printFirstFunction(c *cobra.Command, dirs []string, scriptName string) error {
+	var err error
+	for _, dir := range dirs {
+		if err = printFunction(c, dir, scriptName); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func printFunction(c *cobra.Command, shellName, scriptName string) error {
 	in, err := functions.Open(fmt.Sprintf("data/%s/%s", shellName, scriptName))
 	if err != nil {
@@ -1,6 +1,13 @@
 package sfg
 
-import "os"
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func Example_Init() {
 	cmd := New()
@@ -9,13 +16,14 @@ func Example_Init() {
 	cmd.Execute()
 	// Output:
 	// cdrrh(){
-	//     to_path=$(rrh repository info --entry path $1)
+	//     if [[ $# -eq 0 ]]; then
+	//         to_path=$(rrh pick)
+	//     else
+	//         to_path=$(rrh jump "$@")
+	//     fi
 	//     if [[ $? -eq 0 ]]; then
-	//         if [[ $(echo $to_path | wc -l) -ne 1 ]]; then
-	//             echo "Error: multiple paths are given."
-	//             return 1
-	//         fi
-	//         cd ${to_path#Path: }
+	//         cd "$to_path"
+	//         rrh touch "$to_path" 2> /dev/null
 	//         pwd
 	//     else
 	//         return 1
@@ -30,21 +38,78 @@ func Example_Init() {
 	// }
 	// compdef _cdrrh cdrrh
 	// rrhpeco(){
-	//     csv=$(rrh list --format table | peco)
-	//     if [[ $(echo $csv | wc -l) -ne 1 ]]; then
+	//     candidate=$(rrh jump --list | peco)
+	//     if [[ $(echo "$candidate" | wc -l) -ne 1 ]]; then
 	//         echo "multiple entries are given"
 	//         return 1
 	//     fi
-	//     cd $(echo $csv | awk -F | '{ print $4 }' | tr -d ' ')
+	//     to_path=$(echo "$candidate" | cut -f 3)
+	//     cd "$to_path"
+	//     rrh touch "$to_path" 2> /dev/null
 	//     pwd
 	// }
 	// rrhfzf(){
-	//     csv=$(rrh list --format table | fzf)
-	//     if [[ $(echo $csv | wc -l) -ne 1 ]]; then
+	//     candidate=$(rrh jump --list | fzf)
+	//     if [[ $(echo "$candidate" | wc -l) -ne 1 ]]; then
 	//         echo "multiple entries are given"
 	//         return 1
 	//     fi
-	//     cd $(echo $csv | awk -F | '{ print $4 }' | tr -d ' ')
+	//     to_path=$(echo "$candidate" | cut -f 3)
+	//     cd "$to_path"
+	//     rrh touch "$to_path" 2> /dev/null
 	//     pwd
 	// }
 }
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGoldenFiles(t *testing.T) {
+	var testcases = []struct {
+		args     []string
+		shellEnv string
+		hasError bool
+		golden   string
+	}{
+		{[]string{"--shell", "bash"}, "", false, "bash.golden"},
+		{[]string{"--shell", "zsh"}, "", false, "zsh.golden"},
+		{[]string{"--shell", "fish"}, "", false, "fish.golden"},
+		{[]string{"--shell", "nushell"}, "", false, "nushell.golden"},
+		{[]string{"--shell", "nu"}, "", false, "nushell.golden"},
+		{[]string{"--shell", "FISH"}, "/bin/bash", false, "fish.golden"},
+		{[]string{}, "/usr/local/bin/fish", false, "fish.golden"},
+		{[]string{}, "/opt/homebrew/bin/nu", false, "nushell.golden"},
+		{[]string{"--without-rrhpeco", "--without-rrhfzf"}, "/bin/zsh", false, "zsh_cdrrh.golden"},
+		{[]string{"--hook", "--without-cdrrh", "--without-rrhpeco", "--without-rrhfzf"}, "/bin/bash", false, "bash_hook.golden"},
+		{[]string{"--hook", "--without-cdrrh", "--without-rrhpeco", "--without-rrhfzf"}, "/bin/zsh", false, "zsh_hook.golden"},
+		{[]string{"--hook", "--without-cdrrh", "--without-rrhpeco", "--without-rrhfzf"}, "/usr/bin/fish", false, "fish_hook.golden"},
+		{[]string{"--hook"}, "/usr/bin/nu", true, ""},
+		{[]string{"--shell", "tcsh"}, "", true, ""},
+		{[]string{}, "/bin/tcsh", true, ""},
+		{[]string{}, "", true, ""},
+	}
+	var shell = os.Getenv("SHELL")
+	defer os.Setenv("SHELL", shell)
+	for _, tc := range testcases {
+		os.Setenv("SHELL", tc.shellEnv)
+		var out = bytes.NewBuffer([]byte{})
+		var cmd = New()
+		cmd.SetOut(out)
+		cmd.SetErr(ioutil.Discard)
+		cmd.SetArgs(tc.args)
+		var err = cmd.Execute()
+		if (err != nil) != tc.hasError {
+			t.Errorf("%v (SHELL=%s): status code did not match, wont: %v, got: %v", tc.args, tc.shellEnv, tc.hasError, err)
+		}
+		if tc.hasError {
+			continue
+		}
+		var path = filepath.Join("testdata", tc.golden)
+		if *update {
+			ioutil.WriteFile(path, out.Bytes(), 0644)
+		}
+		var wont, _ = ioutil.ReadFile(path)
+		if out.String() != string(wont) {
+			t.Errorf("%v (SHELL=%s): output did not match the golden file %s, got: %s", tc.args, tc.shellEnv, path, out.String())
+		}
+	}
+}
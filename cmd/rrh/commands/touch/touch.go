@@ -0,0 +1,57 @@
+package touch
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/cmd/rrh/commands/utils"
+	"github.com/tamada/rrh/common"
+)
+
+func New() *cobra.Command {
+	touchCommand := &cobra.Command{
+		Use:   "touch [REPOSITORY_ID|PATH...]",
+		Short: "update the last accessed time of the given repositories",
+		Long: `update the last accessed time of the given repositories.
+The arguments are the repository ids or the paths in the repositories.
+If no arguments are given, the repository containing the current directory is the target.
+This command prints nothing, for calling from the shell functions (e.g., cdrrh).`,
+		ValidArgsFunction: utils.CompleteRepositories,
+		RunE: func(c *cobra.Command, args []string) error {
+			return utils.PerformRrhCommand(c, args, perform)
+		},
+	}
+	return touchCommand
+}
+
+/*
+findRepository returns the repository of the given id, or the repository containing the given path.
+*/
+func findRepository(db *rrh.Database, arg string) (*rrh.Repository, error) {
+	if repo := db.FindRepository(arg); repo != nil {
+		return repo, nil
+	}
+	if repo := db.FindRepositoryByPath(arg); repo != nil {
+		return repo, nil
+	}
+	return nil, fmt.Errorf("%s: repository not found", arg)
+}
+
+func perform(c *cobra.Command, args []string, db *rrh.Database) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var el = common.NewErrorList()
+	for _, arg := range args {
+		var repo, err = findRepository(db, arg)
+		if err == nil {
+			err = db.Touch(repo.ID)
+		}
+		el = el.Append(err)
+	}
+	if err := el.NilOrThis(); err != nil {
+		return err
+	}
+	return db.StoreAndClose()
+}
@@ -0,0 +1,44 @@
+package touch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tamada/rrh"
+)
+
+func TestTouch(t *testing.T) {
+	var dir, _ = filepath.Abs(".")
+	var testcases = []struct {
+		args        []string
+		hasError    bool
+		wontTouched []string
+	}{
+		{[]string{"repo1"}, false, []string{"repo1"}},
+		{[]string{"repo1", "repo2"}, false, []string{"repo1", "repo2"}},
+		{[]string{filepath.Join(dir, "sub")}, false, []string{"touch"}},
+		{[]string{}, false, []string{"touch"}},
+		{[]string{filepath.Join(filepath.Dir(dir), "unknown")}, true, []string{}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("touch", dir, "", []*rrh.Remote{})
+			db.StoreAndClose()
+
+			var cmd = New()
+			cmd.SetArgs(tc.args)
+			var err = cmd.Execute()
+			if (err != nil) != tc.hasError {
+				t.Errorf("%v: status code did not match, wont: %v, got: %v", tc.args, tc.hasError, err)
+			}
+			var db2, _ = rrh.Open(config)
+			for _, repoID := range tc.wontTouched {
+				if repo := db2.FindRepository(repoID); repo == nil || repo.LastAccessed == nil {
+					t.Errorf("%v: %s should be touched", tc.args, repoID)
+				}
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+)
+
+/*
+CompletionFunc is the type of the functions for the dynamic shell completion.
+*/
+type CompletionFunc func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+func openDatabaseForCompletion() *rrh.Database {
+	var db, err = rrh.Open(rrh.OpenConfig())
+	if err != nil {
+		return nil
+	}
+	return db
+}
+
+/*
+splitSelector splits the given string into the prefix and the last item of the group selector,
+such as "work,!le" into "work,!" and "le".
+*/
+func splitSelector(toComplete string) (string, string) {
+	var index = strings.LastIndexAny(toComplete, ",&!")
+	return toComplete[:index+1], toComplete[index+1:]
+}
+
+func filterCandidates(candidates []string, prefix, toComplete string, excludes []string) []string {
+	var results = []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) && !rrh.FindIn(candidate, excludes) {
+			results = append(results, prefix+candidate)
+		}
+	}
+	return results
+}
+
+func repositoryIDs(db *rrh.Database) []string {
+	var results = []string{}
+	for _, repo := range db.Repositories {
+		results = append(results, repo.ID)
+	}
+	return results
+}
+
+func groupNames(db *rrh.Database) []string {
+	var results = []string{}
+	for _, group := range db.Groups {
+		results = append(results, group.Name)
+	}
+	return results
+}
+
+/*
+CompleteRepositories completes the repository ids in the database.
+The repositories already given in the arguments are excluded,
+and the comma separated ids (e.g., "--repositories repo1,re") are also completed by the last item.
+*/
+func CompleteRepositories(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var db = openDatabaseForCompletion()
+	if db == nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var index = strings.LastIndex(toComplete, ",")
+	return filterCandidates(repositoryIDs(db), toComplete[:index+1], toComplete[index+1:], args), cobra.ShellCompDirectiveNoFileComp
+}
+
+/*
+CompleteRepository completes the repository id only for the first argument.
+*/
+func CompleteRepository(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return CompleteRepositories(c, args, toComplete)
+}
+
+/*
+CompleteGroups completes the group names in the database.
+The group selectors (e.g., "work,!legacy") are also completed by the last item.
+*/
+func CompleteGroups(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var db = openDatabaseForCompletion()
+	if db == nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var prefix, item = splitSelector(toComplete)
+	return filterCandidates(groupNames(db), prefix, item, args), cobra.ShellCompDirectiveNoFileComp
+}
+
+/*
+CompleteGroup completes the group name only for the first argument.
+*/
+func CompleteGroup(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return CompleteGroups(c, args, toComplete)
+}
+
+/*
+CompleteGroupsAndRepositories completes the group names, the repository ids, and the GROUP/REPOSITORY forms.
+*/
+func CompleteGroupsAndRepositories(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var db = openDatabaseForCompletion()
+	if db == nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates = append(groupNames(db), repositoryIDs(db)...)
+	for _, relation := range db.Relations {
+		candidates = append(candidates, relation.GroupName+"/"+relation.RepositoryID)
+	}
+	return filterCandidates(candidates, "", toComplete, []string{}), cobra.ShellCompDirectiveNoFileComp
+}
+
+/*
+CompleteRemotes completes the remote names of the repositories given in the arguments.
+If no repositories are given, the remote names of all of the repositories are completed.
+*/
+func CompleteRemotes(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var db = openDatabaseForCompletion()
+	if db == nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names = []string{}
+	for _, repo := range db.Repositories {
+		if len(args) > 0 && !rrh.FindIn(repo.ID, args) {
+			continue
+		}
+		for _, remote := range repo.Remotes {
+			if !rrh.FindIn(remote.Name, names) {
+				names = append(names, remote.Name)
+			}
+		}
+	}
+	return filterCandidates(names, "", toComplete, []string{}), cobra.ShellCompDirectiveNoFileComp
+}
+
+/*
+CompleteValues returns the completion function for the given fixed values.
+The comma separated values (e.g., "--entry id,path") are also completed by the last item.
+*/
+func CompleteValues(values ...string) CompletionFunc {
+	return func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var index = strings.LastIndex(toComplete, ",")
+		return filterCandidates(values, toComplete[:index+1], toComplete[index+1:], []string{}), cobra.ShellCompDirectiveNoFileComp
+	}
+}
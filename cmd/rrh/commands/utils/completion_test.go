@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tamada/rrh"
+)
+
+func TestCompletions(t *testing.T) {
+	var testcases = []struct {
+		name       string
+		f          CompletionFunc
+		args       []string
+		toComplete string
+		wont       []string
+	}{
+		{"repositories", CompleteRepositories, []string{}, "", []string{"repo1", "repo2"}},
+		{"repositories", CompleteRepositories, []string{"repo1"}, "", []string{"repo2"}},
+		{"repositories", CompleteRepositories, []string{}, "repo1,re", []string{"repo1,repo1", "repo1,repo2"}},
+		{"repository", CompleteRepository, []string{"repo1"}, "", []string{}},
+		{"groups", CompleteGroups, []string{}, "group", []string{"group1", "group2", "group3"}},
+		{"groups", CompleteGroups, []string{}, "group1,!group3", []string{"group1,!group3"}},
+		{"groups", CompleteGroups, []string{}, "group1&g", []string{"group1&group1", "group1&group2", "group1&group3"}},
+		{"group", CompleteGroup, []string{"group1"}, "", []string{}},
+		{"groups and repositories", CompleteGroupsAndRepositories, []string{}, "group1", []string{"group1", "group1/repo1"}},
+		{"remotes", CompleteRemotes, []string{}, "", []string{"origin"}},
+		{"remotes", CompleteRemotes, []string{"repo1"}, "", []string{}},
+		{"values", CompleteValues("csv", "json", "table"), []string{}, "", []string{"csv", "json", "table"}},
+		{"values", CompleteValues("id", "path", "group"), []string{}, "id,p", []string{"id,path"}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../../../../testdata/test_db.json", "../../../../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			var got, directive = tc.f(&cobra.Command{}, tc.args, tc.toComplete)
+			if strings.Join(got, ",") != strings.Join(tc.wont, ",") {
+				t.Errorf("%s(%v, %s) did not match, wont: %v, got: %v", tc.name, tc.args, tc.toComplete, tc.wont, got)
+			}
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("%s(%v, %s) directive did not match, wont: %v, got: %v", tc.name, tc.args, tc.toComplete, cobra.ShellCompDirectiveNoFileComp, directive)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
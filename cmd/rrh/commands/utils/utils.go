@@ -90,3 +90,13 @@ func JoinArray(array []string) string {
 // 	}
 // 	return fmt.Sprintf("%s is invalid %s", e.Field(), e.Value())
 // }
+
+/*
+AddWalkFlags registers the flags for searching the git repositories in the directories to the given command.
+*/
+func AddWalkFlags(c *cobra.Command, opt *rrh.WalkOption) {
+	flags := c.Flags()
+	flags.IntVarP(&opt.MaxDepth, "max-depth", "", 0, "specify the maximum depth of the directories to search (0 means unlimited)")
+	flags.StringSliceVarP(&opt.Ignores, "ignore", "", []string{}, "specify the glob patterns of the directories to skip")
+	flags.BoolVarP(&opt.FollowSymlinks, "follow-symlinks", "", false, "follow the symbolic links to the directories")
+}
@@ -13,14 +13,23 @@ import (
 	"github.com/tamada/rrh/cmd/rrh/commands/alias"
 	"github.com/tamada/rrh/cmd/rrh/commands/clone"
 	"github.com/tamada/rrh/cmd/rrh/commands/config"
+	"github.com/tamada/rrh/cmd/rrh/commands/doctor"
 	"github.com/tamada/rrh/cmd/rrh/commands/execcmd"
 	"github.com/tamada/rrh/cmd/rrh/commands/group"
+	"github.com/tamada/rrh/cmd/rrh/commands/here"
+	"github.com/tamada/rrh/cmd/rrh/commands/hook"
+	"github.com/tamada/rrh/cmd/rrh/commands/jump"
 	"github.com/tamada/rrh/cmd/rrh/commands/list"
 	"github.com/tamada/rrh/cmd/rrh/commands/migrate"
+	"github.com/tamada/rrh/cmd/rrh/commands/move"
 	"github.com/tamada/rrh/cmd/rrh/commands/open"
+	"github.com/tamada/rrh/cmd/rrh/commands/pick"
 	"github.com/tamada/rrh/cmd/rrh/commands/prune"
+	"github.com/tamada/rrh/cmd/rrh/commands/relocate"
 	"github.com/tamada/rrh/cmd/rrh/commands/repository"
+	"github.com/tamada/rrh/cmd/rrh/commands/scan"
 	"github.com/tamada/rrh/cmd/rrh/commands/sfg"
+	"github.com/tamada/rrh/cmd/rrh/commands/touch"
 )
 
 var (
@@ -34,6 +43,12 @@ func rootCommand() *cobra.Command {
 		Use:     "rrh",
 		Version: rrh.VERSION,
 		Args:    cobra.ArbitraryArgs,
+		ValidArgsFunction: func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return alias.CompleteAliases(c, args, toComplete)
+		},
 		RunE: func(c *cobra.Command, args []string) error {
 			config := rrh.OpenConfig()
 			if len(args) == 0 {
@@ -63,14 +78,23 @@ func registerSubCommands(c *cobra.Command) {
 	c.AddCommand(add.New())
 	c.AddCommand(clone.New())
 	c.AddCommand(config.New())
+	c.AddCommand(doctor.New())
 	c.AddCommand(execcmd.New())
 	c.AddCommand(group.New())
+	c.AddCommand(here.New())
+	c.AddCommand(hook.New())
+	c.AddCommand(jump.New())
 	c.AddCommand(list.New())
 	c.AddCommand(open.New())
+	c.AddCommand(pick.New())
 	c.AddCommand(prune.New())
+	c.AddCommand(relocate.New())
 	c.AddCommand(repository.New())
+	c.AddCommand(scan.New())
 	c.AddCommand(migrate.New())
+	c.AddCommand(move.New())
 	c.AddCommand(sfg.New())
+	c.AddCommand(touch.New())
 }
 
 func loadAndFindAlias(c *cobra.Command, args []string, config *rrh.Config) (*alias.Command, error) {
@@ -20,6 +20,7 @@ The environment variable names.
 */
 const (
 	AliasPath        = "RRH_ALIAS_PATH"
+	AssignmentRules  = "RRH_ASSIGNMENT_RULES"
 	AutoDeleteGroup  = "RRH_AUTO_DELETE_GROUP"
 	AutoCreateGroup  = "RRH_AUTO_CREATE_GROUP"
 	CloneDestination = "RRH_CLONE_DESTINATION"
@@ -27,19 +28,28 @@ const (
 	ConfigPath       = "RRH_CONFIG_PATH"
 	DatabasePath     = "RRH_DATABASE_PATH"
 	DefaultGroupName = "RRH_DEFAULT_GROUP_NAME"
+	EditorCommand    = "RRH_EDITOR_COMMAND"
 	EnableColorized  = "RRH_ENABLE_COLORIZED"
+	Forges           = "RRH_FORGES"
 	Home             = "RRH_HOME"
+	HookIgnores      = "RRH_HOOK_IGNORES"
+	HookMode         = "RRH_HOOK_MODE"
+	Hostname         = "RRH_HOSTNAME"
+	Openers          = "RRH_OPENERS"
 	SortOnUpdating   = "RRH_SORT_ON_UPDATING"
+	TerminalCommand  = "RRH_TERMINAL_COMMAND"
 	TimeFormat       = "RRH_TIME_FORMAT"
+	WorkspaceRoots   = "RRH_WORKSPACE_ROOTS"
 )
 
 /*
 AvailableLabels represents the labels availables in the config.
 */
 var AvailableLabels = []string{
-	AliasPath, AutoCreateGroup, AutoDeleteGroup, CloneDestination,
-	ColorSetting, ConfigPath, DatabasePath, DefaultGroupName,
-	EnableColorized, Home, SortOnUpdating, TimeFormat,
+	AliasPath, AssignmentRules, AutoCreateGroup, AutoDeleteGroup, CloneDestination,
+	ColorSetting, ConfigPath, DatabasePath, DefaultGroupName, EditorCommand,
+	EnableColorized, Forges, Home, HookIgnores, HookMode, Hostname, Openers, SortOnUpdating,
+	TerminalCommand, TimeFormat, WorkspaceRoots,
 }
 var boolLabels = []string{
 	AutoCreateGroup, AutoDeleteGroup, EnableColorized,
@@ -94,6 +104,7 @@ type ReadFrom string
 var defaultValues = Config{
 	values: map[string]string{
 		AliasPath:        "${RRH_HOME}/alias.json",
+		AssignmentRules:  "",
 		AutoCreateGroup:  "false",
 		AutoDeleteGroup:  "false",
 		CloneDestination: ".",
@@ -101,10 +112,18 @@ var defaultValues = Config{
 		ConfigPath:       "${RRH_HOME}/config.json",
 		DatabasePath:     "${RRH_HOME}/database.json",
 		DefaultGroupName: "no-group",
+		EditorCommand:    "",
 		EnableColorized:  "false",
+		Forges:           "",
 		Home:             "${HOME}/.config/rrh",
+		HookIgnores:      "",
+		HookMode:         HookOffer,
+		Hostname:         "",
+		Openers:          "",
 		SortOnUpdating:   "false",
+		TerminalCommand:  "",
 		TimeFormat:       Relative,
+		WorkspaceRoots:   "",
 	},
 	Color: &Color{},
 }
@@ -135,6 +154,23 @@ func contains(slice []string, label string) bool {
 	return false
 }
 
+/*
+ValueCandidates returns the candidates of the value of the given label, for the shell completion.
+If the value of the label is free form, this function returns the empty slice.
+*/
+func ValueCandidates(label string) []string {
+	if contains(boolLabels, label) {
+		return []string{trueString, falseString}
+	}
+	if label == TimeFormat {
+		return []string{Relative}
+	}
+	if label == HookMode {
+		return []string{HookOffer, HookAuto}
+	}
+	return []string{}
+}
+
 /*
 Unset method deletes the specified config value.
 */
@@ -174,6 +210,33 @@ func (config *Config) Update(label string, value string) error {
 	if contains(boolLabels, label) {
 		return config.updateBoolValue(label, value)
 	}
+	if label == WorkspaceRoots {
+		if _, err := ParseWorkspaceRoots(value); err != nil {
+			return err
+		}
+	}
+	if label == AssignmentRules {
+		if _, err := ParseAssignmentRules(value); err != nil {
+			return err
+		}
+	}
+	if label == Forges {
+		if _, err := ParseForges(value); err != nil {
+			return err
+		}
+	}
+	if label == Openers {
+		if _, err := ParseOpeners(value); err != nil {
+			return err
+		}
+	}
+	if label == HookMode {
+		var mode, err = normalizeHookMode(value)
+		if err != nil {
+			return err
+		}
+		value = mode
+	}
 	config.values[label] = value
 	return nil
 }
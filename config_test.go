@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -196,3 +197,21 @@ func TestOpenConfigBrokenJson(t *testing.T) {
 		t.Error("broken json returns nil")
 	}
 }
+
+func TestValueCandidates(t *testing.T) {
+	var testcases = []struct {
+		label string
+		wont  []string
+	}{
+		{AutoCreateGroup, []string{"true", "false"}},
+		{TimeFormat, []string{Relative}},
+		{HookMode, []string{HookOffer, HookAuto}},
+		{DatabasePath, []string{}},
+	}
+	for _, tc := range testcases {
+		var got = ValueCandidates(tc.label)
+		if strings.Join(got, ",") != strings.Join(tc.wont, ",") {
+			t.Errorf("ValueCandidates(%s) did not match, wont: %v, got: %v", tc.label, tc.wont, got)
+		}
+	}
+}
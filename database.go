@@ -22,21 +22,40 @@ func (r *Remote) String() string {
 
 /*
 Repository represents a Git repository.
+If Root is not empty, Path is stored as the relative path from the workspace root of the name in the database file.
 */
 type Repository struct {
-	ID          string    `json:"repository_id"`
-	Path        string    `json:"repository_path"`
-	Description string    `json:"repository_desc"`
-	Remotes     []*Remote `json:"remotes"`
+	ID           string      `json:"repository_id"`
+	Root         string      `json:"root,omitempty"`
+	Path         string      `json:"repository_path"`
+	Description  string      `json:"repository_desc"`
+	Remotes      []*Remote   `json:"remotes"`
+	Tags         []*Tag      `json:"tags,omitempty"`
+	HostPaths    []*HostPath `json:"host_paths,omitempty"`
+	AddedAt      *RrhTime    `json:"added_at,omitempty"`
+	UpdatedAt    *RrhTime    `json:"updated_at,omitempty"`
+	LastAccessed *RrhTime    `json:"last_accessed,omitempty"`
+	AccessCount  int         `json:"access_count,omitempty"`
+
+	current     *HostPath
+	defaultRoot string
+	defaultPath string
+	fileMatches map[string]bool
 }
 
 /*
 Group represents the groups of the Git repositories.
+The group may have a parent group for constructing the group hierarchy.
+The group with rules is the dynamic group, and its members are computed from the rules at read time.
 */
 type Group struct {
-	Name        string `json:"group_name"`
-	Description string `json:"group_desc"`
-	OmitList    bool   `json:"omit_list"`
+	Name        string   `json:"group_name"`
+	Description string   `json:"group_desc"`
+	OmitList    bool     `json:"omit_list"`
+	Parent      string   `json:"parent,omitempty"`
+	Rules       []string `json:"rules,omitempty"`
+
+	parsed *parsedRules
 }
 
 func (r *Repository) Identifier() string {
@@ -74,9 +93,14 @@ func groupFrequencies(db *Database) map[string]int {
 	var groupMap = map[string]int{}
 	for _, group := range db.Groups {
 		groupMap[group.Name] = 0
+		if group.IsDynamic() {
+			groupMap[group.Name] = len(findDynamicMembers(db, group))
+		}
 	}
 	for _, relation := range db.Relations {
-		groupMap[relation.GroupName] = groupMap[relation.GroupName] + 1
+		if !db.isDynamicGroup(relation.GroupName) {
+			groupMap[relation.GroupName] = groupMap[relation.GroupName] + 1
+		}
 	}
 	return groupMap
 }
@@ -85,7 +109,7 @@ func pruneGroups(db *Database) []*Group {
 	var groupMap = groupFrequencies(db)
 	var prunedGroups = []*Group{}
 	for _, group := range db.Groups {
-		if groupMap[group.Name] == 0 {
+		if !group.IsDynamic() && groupMap[group.Name] == 0 && len(db.FindChildGroups(group.Name)) == 0 {
 			prunedGroups = append(prunedGroups, group)
 		}
 	}
@@ -95,10 +119,12 @@ func pruneGroups(db *Database) []*Group {
 func repositoryFrequencies(db *Database) map[string]int {
 	var repoFlags = map[string]int{}
 	for _, repo := range db.Repositories {
-		repoFlags[repo.ID] = 0
+		repoFlags[repo.ID] = len(findDynamicGroupsOf(db, repo))
 	}
 	for _, relation := range db.Relations {
-		repoFlags[relation.RepositoryID] = repoFlags[relation.RepositoryID] + 1
+		if !db.isDynamicGroup(relation.GroupName) {
+			repoFlags[relation.RepositoryID] = repoFlags[relation.RepositoryID] + 1
+		}
 	}
 	return repoFlags
 }
@@ -180,7 +206,8 @@ func (db *Database) CreateRepository(repoID string, path string, desc string, re
 	if err != nil {
 		return nil, err
 	}
-	var repo = &Repository{repoID, absPath, desc, remotes}
+	var now = Now()
+	var repo = &Repository{ID: repoID, Path: absPath, Description: desc, Remotes: remotes, AddedAt: &now, UpdatedAt: &now}
 	db.Repositories = append(db.Repositories, repo)
 	sortIfNeeded(db)
 
@@ -209,7 +236,7 @@ func (db *Database) CreateGroup(groupID string, description string, omitList boo
 	if db.HasGroup(groupID) {
 		return nil, fmt.Errorf("%s: already registered group", groupID)
 	}
-	var group = &Group{groupID, description, omitList}
+	var group = &Group{Name: groupID, Description: description, OmitList: omitList}
 	db.Groups = append(db.Groups, group)
 	sortIfNeeded(db)
 
@@ -218,12 +245,16 @@ func (db *Database) CreateGroup(groupID string, description string, omitList boo
 
 /*
 UpdateGroup updates found group with `newGroupID` and `newDescription`.
+If the name of the group is changed, the parents of the child groups are also updated.
 The return value is that the update is success or not.
 */
 func (db *Database) UpdateGroup(groupID string, newGroup *Group) bool {
 	if !db.HasGroup(groupID) {
 		return false
 	}
+	if groupID != newGroup.Name {
+		reparentChildren(db, groupID, newGroup.Name)
+	}
 	for i, group := range db.Groups {
 		if group.Name == groupID {
 			db.Groups[i] = newGroup
@@ -256,6 +287,8 @@ func updateRepositoryImpl(db *Database, index int, newRepo Repository) {
 	db.Repositories[index].ID = newRepo.ID
 	db.Repositories[index].Description = newRepo.Description
 	db.Repositories[index].Path = newRepo.Path
+	db.Repositories[index].Remotes = newRepo.Remotes
+	db.Repositories[index].MarkUpdated()
 	for i, rel := range db.Relations {
 		if rel.RepositoryID == oldID {
 			db.Relations[i].RepositoryID = newRepo.ID
@@ -268,8 +301,12 @@ func updateRepositoryImpl(db *Database, index int, newRepo Repository) {
 Relate create the relation between the group and the repository.
 The group and the repository are specified by the given parameters.
 If the group and the repository have the relation, this function returns `nil` (successfully create relation).
+The dynamic groups are read-only, therefore, this function returns an error for them.
 */
 func (db *Database) Relate(groupID string, repoID string) error {
+	if db.isDynamicGroup(groupID) {
+		return fmt.Errorf("%s: could not relate %s to the dynamic group", groupID, repoID)
+	}
 	if db.HasRelation(groupID, repoID) {
 		return nil
 	}
@@ -297,8 +334,12 @@ func (db *Database) ContainsCount(groupID string) int {
 
 /*
 FindRelationsOfGroup returns the repository ids belonging to the specified group.
+The members of the dynamic group are computed from its rules.
 */
 func (db *Database) FindRelationsOfGroup(groupID string) []string {
+	if group := db.FindGroup(groupID); group != nil && group.IsDynamic() {
+		return findDynamicMembers(db, group)
+	}
 	var repositories = []string{}
 	for _, relation := range db.Relations {
 		if relation.GroupName == groupID {
@@ -310,14 +351,18 @@ func (db *Database) FindRelationsOfGroup(groupID string) []string {
 
 /*
 FindRelationsOfRepository returns the group names of the specified repository.
+The dynamic groups whose rules match the repository are also included.
 */
 func (db *Database) FindRelationsOfRepository(repositoryID string) []string {
 	var groups = []string{}
 	for _, relation := range db.Relations {
-		if relation.RepositoryID == repositoryID {
+		if relation.RepositoryID == repositoryID && !db.isDynamicGroup(relation.GroupName) {
 			groups = append(groups, relation.GroupName)
 		}
 	}
+	if repo := db.FindRepository(repositoryID); repo != nil {
+		groups = append(groups, findDynamicGroupsOf(db, repo)...)
+	}
 	return groups
 }
 
@@ -326,6 +371,10 @@ HasRelation returns true if the group and the repository has relation.
 The group and the repository are specified by the given parameters.
 */
 func (db *Database) HasRelation(groupID string, repoID string) bool {
+	if group := db.FindGroup(groupID); group != nil && group.IsDynamic() {
+		var repo = db.FindRepository(repoID)
+		return repo != nil && group.MatchRules(repo)
+	}
 	for _, relation := range db.Relations {
 		if relation.GroupName == groupID && relation.RepositoryID == repoID {
 			return true
@@ -338,10 +387,14 @@ func (db *Database) HasRelation(groupID string, repoID string) bool {
 Unrelate deletes the relation between the group and the repository.
 The group and the repository are specified by the given parameters.
 If the group and the repository do not have the relation, this function returns `nil` (successfully delete relation).
+The dynamic groups are read-only, therefore, this function returns an error for them.
 */
-func (db *Database) Unrelate(groupID string, repoID string) {
+func (db *Database) Unrelate(groupID string, repoID string) error {
+	if db.isDynamicGroup(groupID) {
+		return fmt.Errorf("%s: could not unrelate %s from the dynamic group", groupID, repoID)
+	}
 	if !db.HasRelation(groupID, repoID) {
-		return
+		return nil
 	}
 	var newRelations = []*Relation{}
 	for _, relation := range db.Relations {
@@ -350,6 +403,7 @@ func (db *Database) Unrelate(groupID string, repoID string) {
 		}
 	}
 	db.Relations = newRelations
+	return nil
 }
 
 /*
@@ -436,15 +490,17 @@ func deleteGroup(db *Database, groupID string) error {
 
 /*
 DeleteGroup removes the group of the given groupId from DB.
-If the group has some repositories, the function fails to remove.
+If the group has some repositories or child groups, the function fails to remove.
 */
 func (db *Database) DeleteGroup(groupID string) error {
 	if !db.HasGroup(groupID) {
 		return fmt.Errorf("%s: group not found", groupID)
 	}
-	var groups = groupFrequencies(db)
-	if groups[groupID] != 0 {
-		return fmt.Errorf("%s: group has %d relatins", groupID, groups[groupID])
+	if count := countStaticRelations(db, groupID); count != 0 {
+		return fmt.Errorf("%s: group has %d relatins", groupID, count)
+	}
+	if children := db.FindChildGroups(groupID); len(children) != 0 {
+		return fmt.Errorf("%s: group has %d child groups", groupID, len(children))
 	}
 	return deleteGroup(db, groupID)
 }
@@ -452,12 +508,15 @@ func (db *Database) DeleteGroup(groupID string) error {
 /*
 ForceDeleteGroup removes the group of the given groupID from DB.
 Even if the group has some repositories, the function forcely remove the group.
+The child groups of the removed group are moved to the parent of the removed group.
 */
 func (db *Database) ForceDeleteGroup(groupID string) error {
-	if !db.HasGroup(groupID) {
+	var group = db.FindGroup(groupID)
+	if group == nil {
 		return fmt.Errorf("%s: group not found", groupID)
 	}
 	db.UnrelateFromGroup(groupID)
+	reparentChildren(db, groupID, group.Parent)
 	return deleteGroup(db, groupID)
 }
 
@@ -501,15 +560,19 @@ func Open(config *Config) (*Database, error) {
 		return nil, err
 	}
 	db.Config = config
+	var roots = config.WorkspaceRoots()
+	resolveRepositoryPaths(&db, roots)
+	selectHostPaths(&db, CurrentHostname(config), roots)
 	return &db, nil
 }
 
 /*
 FindTargets returns instances of Relation objects with given groupNames.
+The relations of the descendant groups of the given groups are also included.
 */
 func FindTargets(db *Database, groupNames []string) []Relation {
 	var result = []Relation{}
-	for _, groupName := range groupNames {
+	for _, groupName := range db.ExpandGroupNames(groupNames) {
 		var repos = db.FindRelationsOfGroup(groupName)
 		var relations = toRelations(groupName, repos)
 		result = append(result, relations...)
@@ -45,14 +45,14 @@ func TestAutoCreateGroup(t *testing.T) {
 		t.Errorf("auto create group failed: %s", err.Error())
 	}
 	if !db.HasGroup("newgroup") && group.Description != "desc" && group.OmitList {
-		t.Errorf("auto created group did not match, wont: %v, got: %v", Group{"newgroup", "desc", true}, group)
+		t.Errorf("auto created group did not match, wont: %v, got: %v", Group{Name: "newgroup", Description: "desc", OmitList: true}, group)
 	}
 	var group2, err2 = db.AutoCreateGroup("no-group", "description1", false)
 	if err2 != nil {
 		t.Errorf("auto create group failed: %s", err.Error())
 	}
 	if !db.HasGroup("no-group") && group.Description != "" && group.OmitList {
-		t.Errorf("existing group did not match, wont: %v, got: %v", Group{"group1", "desc1", true}, group2)
+		t.Errorf("existing group did not match, wont: %v, got: %v", Group{Name: "group1", Description: "desc1", OmitList: true}, group2)
 	}
 
 	db.Config.Update(AutoCreateGroup, "false")
@@ -269,7 +269,7 @@ func TestCreateGroupRelateAndUnrelate(t *testing.T) {
 func TestUpdateGroup(t *testing.T) {
 	var db = openDatabase()
 
-	db.UpdateGroup("no-group", &Group{"updated-group", "description", false})
+	db.UpdateGroup("no-group", &Group{Name: "updated-group", Description: "description", OmitList: false})
 	var group = db.FindGroup("updated-group")
 	if group.Name != "updated-group" {
 		t.Error("Update is failed (group name was not updated)")
@@ -278,7 +278,7 @@ func TestUpdateGroup(t *testing.T) {
 		t.Error("Update is failed (description was not updated)")
 	}
 
-	if db.UpdateGroup("unknown", &Group{"never used", "never used2", false}) {
+	if db.UpdateGroup("unknown", &Group{Name: "never used", Description: "never used2", OmitList: false}) {
 		t.Error("unknown group is successfully updated.")
 	}
 }
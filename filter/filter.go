@@ -0,0 +1,326 @@
+/*
+Package filter provides the filter expression language for selecting the repositories.
+
+The expression consists of the comparisons of the repository fields, the boolean fields, and the functions,
+combined with `&&`, `||`, `!`, and the parentheses.
+For example, `group == "work" && remote =~ "github.com/acme" && !tag("archived")`.
+
+The string fields are `id`, `path`, `desc`, `group` (including the ancestor groups), and `remote`
+(both the url and the `HOST/OWNER/REPO` form), compared with `==`, `!=`, `=~` (regular expression), and `!~`.
+If the field has multiple values (e.g., group), the comparison is true when any of the values satisfies it.
+The boolean field is `dirty` (the repository has uncommitted changes).
+The function is `tag(KEY)` or `tag(KEY, VALUE_PATTERN)`.
+*/
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tamada/rrh"
+	"github.com/tamada/rrh/common"
+)
+
+/*
+Error represents the syntax error of the filter expression.
+*/
+type Error struct {
+	Expr    string
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("filter: %s at column %d in %q", e.Message, e.Pos+1, e.Expr)
+}
+
+func newError(expr string, pos int, format string, args ...interface{}) *Error {
+	return &Error{Expr: expr, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+/*
+Filter represents the compiled filter expression.
+*/
+type Filter struct {
+	expr string
+	root node
+}
+
+/*
+Compile parses the given expression and returns the filter.
+The empty expression matches all of repositories.
+*/
+func Compile(expr string) (*Filter, error) {
+	var filter = &Filter{expr: expr}
+	if strings.TrimSpace(expr) == "" {
+		return filter, nil
+	}
+	var root, err = parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	filter.root = root
+	return filter, nil
+}
+
+func (f *Filter) String() string {
+	return f.expr
+}
+
+/*
+IsEmpty returns true if the filter has no conditions.
+*/
+func (f *Filter) IsEmpty() bool {
+	return f == nil || f.root == nil
+}
+
+/*
+Match returns true if the given repository satisfies the filter.
+*/
+func (f *Filter) Match(db *rrh.Database, repo *rrh.Repository) (bool, error) {
+	if f.IsEmpty() {
+		return true, nil
+	}
+	return f.root.eval(&context{db: db, repo: repo})
+}
+
+/*
+Apply returns the ids of the repositories satisfying the filter from the given repository ids.
+The unknown repository ids are ignored.
+*/
+func (f *Filter) Apply(db *rrh.Database, repoIDs []string) ([]string, error) {
+	var el = common.NewErrorList()
+	var results = []string{}
+	for _, repoID := range repoIDs {
+		var repo = db.FindRepository(repoID)
+		if repo == nil {
+			continue
+		}
+		var matched, err = f.Match(db, repo)
+		el = el.Append(err)
+		if matched {
+			results = append(results, repoID)
+		}
+	}
+	return results, el.NilOrThis()
+}
+
+type context struct {
+	db    *rrh.Database
+	repo  *rrh.Repository
+	cache map[string]bool
+}
+
+type node interface {
+	eval(ctx *context) (bool, error)
+}
+
+type andNode struct {
+	left  node
+	right node
+}
+
+func (n *andNode) eval(ctx *context) (bool, error) {
+	var result, err = n.left.eval(ctx)
+	if err != nil || !result {
+		return false, err
+	}
+	return n.right.eval(ctx)
+}
+
+type orNode struct {
+	left  node
+	right node
+}
+
+func (n *orNode) eval(ctx *context) (bool, error) {
+	var result, err = n.left.eval(ctx)
+	if err != nil || result {
+		return result, err
+	}
+	return n.right.eval(ctx)
+}
+
+type notNode struct {
+	child node
+}
+
+func (n *notNode) eval(ctx *context) (bool, error) {
+	var result, err = n.child.eval(ctx)
+	return !result && err == nil, err
+}
+
+type stringField func(ctx *context) []string
+
+type boolField func(ctx *context) (bool, error)
+
+var stringFields = map[string]stringField{
+	"id":     func(ctx *context) []string { return []string{ctx.repo.ID} },
+	"path":   func(ctx *context) []string { return []string{ctx.repo.Path} },
+	"desc":   func(ctx *context) []string { return []string{ctx.repo.Description} },
+	"group":  findGroups,
+	"remote": findRemotes,
+}
+
+var boolFields = map[string]boolField{
+	"dirty": func(ctx *context) (bool, error) { return rrh.IsDirty(ctx.repo) },
+}
+
+func findGroups(ctx *context) []string {
+	var results = []string{}
+	for _, group := range ctx.db.FindRelationsOfRepository(ctx.repo.ID) {
+		for _, name := range append([]string{group}, ctx.db.FindAncestorGroups(group)...) {
+			if !rrh.FindIn(name, results) {
+				results = append(results, name)
+			}
+		}
+	}
+	return results
+}
+
+func findRemotes(ctx *context) []string {
+	var results = []string{}
+	for _, remote := range ctx.repo.Remotes {
+		results = append(results, remote.URL, rrh.ParseRemoteURL(remote.URL).String())
+	}
+	return results
+}
+
+type compareNode struct {
+	name  string
+	field stringField
+	op    tokenKind
+	value string
+	regex *regexp.Regexp
+}
+
+func (n *compareNode) eval(ctx *context) (bool, error) {
+	var values = n.field(ctx)
+	switch n.op {
+	case tokenEqual:
+		return rrh.FindIn(n.value, values), nil
+	case tokenNotEqual:
+		return !rrh.FindIn(n.value, values), nil
+	case tokenMatch:
+		return n.matchAny(values), nil
+	case tokenNotMatch:
+		return !n.matchAny(values), nil
+	}
+	return false, fmt.Errorf("%s: unknown operator", n.op.String())
+}
+
+func (n *compareNode) matchAny(values []string) bool {
+	for _, value := range values {
+		if n.regex.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+type boolFieldNode struct {
+	name  string
+	field boolField
+}
+
+func (n *boolFieldNode) eval(ctx *context) (bool, error) {
+	if ctx.cache == nil {
+		ctx.cache = map[string]bool{}
+	}
+	if value, ok := ctx.cache[n.name]; ok {
+		return value, nil
+	}
+	var value, err = n.field(ctx)
+	if err == nil {
+		ctx.cache[n.name] = value
+	}
+	return value, err
+}
+
+type function struct {
+	minArgs  int
+	maxArgs  int
+	usage    string
+	validate func(args []string) error
+	call     func(ctx *context, args []string) bool
+}
+
+var functions = map[string]function{
+	"tag": {1, 2, "one or two arguments, tag(KEY) or tag(KEY, VALUE_PATTERN)", validateTagArgs,
+		func(ctx *context, args []string) bool { return ctx.repo.MatchTags([]string{tagSelector(args)}) }},
+}
+
+type functionNode struct {
+	name     string
+	function function
+	args     []string
+}
+
+func (n *functionNode) eval(ctx *context) (bool, error) {
+	return n.function.call(ctx, n.args), nil
+}
+
+/*
+AvailableNames returns the names of the available fields and functions in the filter expression.
+*/
+func AvailableNames() []string {
+	var names = []string{}
+	for name := range stringFields {
+		names = append(names, name)
+	}
+	for name := range boolFields {
+		names = append(names, name)
+	}
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func suggest(name string) string {
+	var candidate = ""
+	var best = 3
+	for _, available := range AvailableNames() {
+		if d := distance(name, available); d < best {
+			candidate, best = available, d
+		}
+	}
+	if candidate == "" {
+		return fmt.Sprintf(" (available: %v)", AvailableNames())
+	}
+	return fmt.Sprintf(" (did you mean %q?)", candidate)
+}
+
+func distance(s1, s2 string) int {
+	var r1, r2 = []rune(s1), []rune(s2)
+	var prev = make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		var current = make([]int, len(r2)+1)
+		current[0] = i
+		for j := 1; j <= len(r2); j++ {
+			var cost = 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev = current
+	}
+	return prev[len(r2)]
+}
+
+func minOf(values ...int) int {
+	var result = values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
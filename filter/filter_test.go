@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+)
+
+func TestCompileError(t *testing.T) {
+	var testcases = []struct {
+		expr        string
+		wontMessage string
+	}{
+		{`grop == "work"`, `unknown field "grop" (did you mean "group"?) at column 1`},
+		{`group == "work" && xyzzy`, `unknown field "xyzzy" (available: [desc dirty group id path remote tag]) at column 20`},
+		{`group = "work"`, `unexpected character "=" at column 7`},
+		{`group == work`, `string expected, but got identifier "work" at column 10`},
+		{`group == "work`, `unterminated string at column 10`},
+		{`(group == "work"`, `")" expected, but got end of expression at column 17`},
+		{`remote =~ "[acme"`, `invalid regular expression "[acme"`},
+		{`dirty == "true"`, `dirty is a boolean field, could not be compared at column 7`},
+		{`tag()`, `tag requires one or two arguments`},
+		{`tag("=go")`, `=go: tag key must not be empty`},
+		{`group == "work" "extra"`, `unexpected string "extra" at column 17`},
+		{`&& dirty`, `unexpected "&&" at column 1`},
+	}
+	for _, tc := range testcases {
+		var _, err = Compile(tc.expr)
+		if err == nil {
+			t.Errorf("Compile(%s) should be error", tc.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.wontMessage) {
+			t.Errorf("Compile(%s) error message did not match, wont: %s, got: %s", tc.expr, tc.wontMessage, err.Error())
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var testcases = []struct {
+		expr      string
+		wontRepos []string
+	}{
+		{``, []string{"repo1", "repo2"}},
+		{`group == "group1"`, []string{"repo1"}},
+		{`group != "group1"`, []string{"repo2"}},
+		{`remote =~ "github.com/example"`, []string{"repo2"}},
+		{`remote == "git@github.com:example/repo2.git"`, []string{"repo2"}},
+		{`remote !~ 'example' && id =~ "^repo"`, []string{"repo1"}},
+		{`tag("lang", "go") || id == "repo2"`, []string{"repo1", "repo2"}},
+		{`!tag("archived")`, []string{"repo2"}},
+		{`!(group == "group1" || group == "group3")`, []string{}},
+		{`group == "parent"`, []string{"repo1"}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../testdata/test_db.json", "../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateGroup("parent", "", false)
+			db.SetParentGroup("group1", "parent")
+			db.TagRepository("repo1", []*rrh.Tag{{Key: "lang", Value: "go"}, {Key: "archived"}})
+			var filter, err = Compile(tc.expr)
+			if err != nil {
+				t.Errorf("Compile(%s) failed: %s", tc.expr, err.Error())
+				return
+			}
+			var repos, err2 = filter.Apply(db, []string{"repo1", "repo2", "unknown"})
+			if err2 != nil {
+				t.Errorf("Apply(%s) failed: %s", tc.expr, err2.Error())
+			}
+			if strings.Join(repos, ",") != strings.Join(tc.wontRepos, ",") {
+				t.Errorf("Apply(%s) did not match, wont: %v, got: %v", tc.expr, tc.wontRepos, repos)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestDirtyOnMissingRepository(t *testing.T) {
+	var dbFile = rrh.Rollback("../testdata/test_db.json", "../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		var filter, _ = Compile(`id == "repo1" || dirty`)
+		if matched, err := filter.Match(db, db.FindRepository("repo1")); !matched || err != nil {
+			t.Errorf("dirty should not be evaluated by the short circuit, got: %v, %v", matched, err)
+		}
+		if _, err := filter.Match(db, db.FindRepository("repo2")); err == nil {
+			t.Errorf("dirty of the missing repository should be error")
+		}
+	})
+	defer os.Remove(dbFile)
+}
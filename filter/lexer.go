@@ -0,0 +1,138 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota + 1
+	tokenIdent
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEqual
+	tokenNotEqual
+	tokenMatch
+	tokenNotMatch
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:      "end of expression",
+	tokenIdent:    "identifier",
+	tokenString:   "string",
+	tokenLParen:   "\"(\"",
+	tokenRParen:   "\")\"",
+	tokenComma:    "\",\"",
+	tokenAnd:      "\"&&\"",
+	tokenOr:       "\"||\"",
+	tokenNot:      "\"!\"",
+	tokenEqual:    "\"==\"",
+	tokenNotEqual: "\"!=\"",
+	tokenMatch:    "\"=~\"",
+	tokenNotMatch: "\"!~\"",
+}
+
+func (kind tokenKind) String() string {
+	return tokenNames[kind]
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenIdent:
+		return fmt.Sprintf("identifier %q", t.value)
+	case tokenString:
+		return fmt.Sprintf("string %q", t.value)
+	}
+	return t.kind.String()
+}
+
+var operators = []struct {
+	symbol string
+	kind   tokenKind
+}{
+	{"&&", tokenAnd}, {"||", tokenOr}, {"==", tokenEqual}, {"!=", tokenNotEqual},
+	{"=~", tokenMatch}, {"!~", tokenNotMatch}, {"!", tokenNot},
+	{"(", tokenLParen}, {")", tokenRParen}, {",", tokenComma},
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens = []token{}
+	var runes = []rune(expr)
+	for i := 0; i < len(runes); {
+		var r = runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var value, next, err = readString(expr, runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokenString, value, i})
+			i = next
+		case isIdentRune(r, true):
+			var start = i
+			for i < len(runes) && isIdentRune(runes[i], false) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i]), start})
+		default:
+			var kind, length = findOperator(string(runes[i:]))
+			if kind == 0 {
+				return nil, newError(expr, i, "unexpected character %q", string(r))
+			}
+			tokens = append(tokens, token{kind, string(runes[i : i+length]), i})
+			i += length
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes)}), nil
+}
+
+func isIdentRune(r rune, first bool) bool {
+	if first {
+		return unicode.IsLetter(r) || r == '_'
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+func findOperator(str string) (tokenKind, int) {
+	for _, op := range operators {
+		if strings.HasPrefix(str, op.symbol) {
+			return op.kind, len([]rune(op.symbol))
+		}
+	}
+	return 0, 0
+}
+
+func readString(expr string, runes []rune, start int) (string, int, error) {
+	var quote = runes[start]
+	var builder = strings.Builder{}
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case quote:
+			return builder.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			builder.WriteRune(runes[i])
+		default:
+			builder.WriteRune(runes[i])
+		}
+	}
+	return "", 0, newError(expr, start, "unterminated string")
+}
@@ -0,0 +1,187 @@
+package filter
+
+import (
+	"regexp"
+
+	"github.com/tamada/rrh"
+)
+
+/*
+The grammar of the filter expression is as follows.
+
+	expr    := and ( "||" and )*
+	and     := unary ( "&&" unary )*
+	unary   := "!" unary | primary
+	primary := "(" expr ")"
+	         | FUNCTION "(" STRING ( "," STRING )* ")"
+	         | STRING_FIELD ( "==" | "!=" | "=~" | "!~" ) STRING
+	         | BOOL_FIELD
+*/
+type parser struct {
+	expr   string
+	tokens []token
+	index  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	var t = p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	var t = p.next()
+	if t.kind != kind {
+		return t, newError(p.expr, t.pos, "%s expected, but got %s", kind.String(), t.String())
+	}
+	return t, nil
+}
+
+func parse(expr string) (node, error) {
+	var tokens, err = tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	var p = &parser{expr: expr, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, newError(expr, t.pos, "unexpected %s", t.String())
+	}
+	return root, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	var left, err = p.parseAnd()
+	for err == nil && p.peek().kind == tokenOr {
+		p.next()
+		var right node
+		right, err = p.parseAnd()
+		left = &orNode{left, right}
+	}
+	return left, err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	var left, err = p.parseUnary()
+	for err == nil && p.peek().kind == tokenAnd {
+		p.next()
+		var right node
+		right, err = p.parseUnary()
+		left = &andNode{left, right}
+	}
+	return left, err
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		var child, err = p.parseUnary()
+		return &notNode{child}, err
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	var t = p.next()
+	switch t.kind {
+	case tokenLParen:
+		var child, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokenRParen)
+		return child, err
+	case tokenIdent:
+		return p.parseIdent(t)
+	}
+	return nil, newError(p.expr, t.pos, "unexpected %s", t.String())
+}
+
+func (p *parser) parseIdent(t token) (node, error) {
+	if function, ok := functions[t.value]; ok {
+		return p.parseFunction(t, function)
+	}
+	if field, ok := boolFields[t.value]; ok {
+		if isOperator(p.peek().kind) {
+			return nil, newError(p.expr, p.peek().pos, "%s is a boolean field, could not be compared", t.value)
+		}
+		return &boolFieldNode{name: t.value, field: field}, nil
+	}
+	if field, ok := stringFields[t.value]; ok {
+		return p.parseComparison(t, field)
+	}
+	return nil, newError(p.expr, t.pos, "unknown field %q%s", t.value, suggest(t.value))
+}
+
+func isOperator(kind tokenKind) bool {
+	return kind == tokenEqual || kind == tokenNotEqual || kind == tokenMatch || kind == tokenNotMatch
+}
+
+func (p *parser) parseComparison(t token, field stringField) (node, error) {
+	var op = p.next()
+	if !isOperator(op.kind) {
+		return nil, newError(p.expr, op.pos, "%s expects one of ==, !=, =~, and !~, but got %s", t.value, op.String())
+	}
+	var value, err = p.expect(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	var node = &compareNode{name: t.value, field: field, op: op.kind, value: value.value}
+	if op.kind == tokenMatch || op.kind == tokenNotMatch {
+		var regex, err = regexp.Compile(value.value)
+		if err != nil {
+			return nil, newError(p.expr, value.pos, "invalid regular expression %q (%s)", value.value, err.Error())
+		}
+		node.regex = regex
+	}
+	return node, nil
+}
+
+func (p *parser) parseFunction(t token, function function) (node, error) {
+	if _, err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	var args = []string{}
+	for p.peek().kind != tokenRParen {
+		if len(args) > 0 {
+			if _, err := p.expect(tokenComma); err != nil {
+				return nil, err
+			}
+		}
+		var arg, err = p.expect(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg.value)
+	}
+	p.next()
+	if len(args) < function.minArgs || len(args) > function.maxArgs {
+		return nil, newError(p.expr, t.pos, "%s requires %s", t.value, function.usage)
+	}
+	if function.validate != nil {
+		if err := function.validate(args); err != nil {
+			return nil, newError(p.expr, t.pos, "%s", err.Error())
+		}
+	}
+	return &functionNode{name: t.value, function: function, args: args}, nil
+}
+
+func validateTagArgs(args []string) error {
+	return rrh.ValidateTagSelectors([]string{tagSelector(args)})
+}
+
+func tagSelector(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	return args[0] + "=" + args[1]
+}
@@ -0,0 +1,198 @@
+package rrh
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/*
+Forge represents the kind of the hosting services of the git repositories.
+The kind decides the url layout of the web pages.
+*/
+type Forge string
+
+/*
+The available forges. Gitea also represents Forgejo, since they have the same url layout.
+*/
+const (
+	GitHub    Forge = "github"
+	GitLab    Forge = "gitlab"
+	Gitea     Forge = "gitea"
+	Bitbucket Forge = "bitbucket"
+)
+
+/*
+AvailableForges represents the kinds of the forges, for RRH_FORGES.
+*/
+var AvailableForges = []Forge{GitHub, GitLab, Gitea, Bitbucket}
+
+var wellKnownForges = map[string]Forge{
+	"github.com":    GitHub,
+	"gitlab.com":    GitLab,
+	"gitea.com":     Gitea,
+	"codeberg.org":  Gitea,
+	"bitbucket.org": Bitbucket,
+}
+
+/*
+ParseForge parses the given name of the forge. `forgejo` is parsed as Gitea.
+*/
+func ParseForge(name string) (Forge, error) {
+	var lower = strings.ToLower(strings.TrimSpace(name))
+	if lower == "forgejo" {
+		return Gitea, nil
+	}
+	for _, forge := range AvailableForges {
+		if string(forge) == lower {
+			return forge, nil
+		}
+	}
+	return "", fmt.Errorf("%s: unknown forge (must be github, gitlab, gitea, forgejo, or bitbucket)", name)
+}
+
+/*
+ParseForges parses the value of RRH_FORGES (e.g., `git.example.com=gitlab,code.example.org=forgejo`),
+and returns the map from the hosts to the forges.
+*/
+func ParseForges(value string) (map[string]Forge, error) {
+	var forges = map[string]Forge{}
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		var entries = strings.SplitN(item, "=", 2)
+		if len(entries) != 2 || strings.TrimSpace(entries[0]) == "" {
+			return nil, fmt.Errorf("%s: invalid forge, the forge must be HOST=FORGE form", item)
+		}
+		var forge, err = ParseForge(entries[1])
+		if err != nil {
+			return nil, err
+		}
+		forges[strings.ToLower(strings.TrimSpace(entries[0]))] = forge
+	}
+	return forges, nil
+}
+
+/*
+FindForge returns the forge of the given host.
+The hosts in RRH_FORGES take precedence over the well-known hosts (github.com, gitlab.com, gitea.com, codeberg.org, and bitbucket.org).
+For the other hosts, the forge is guessed from the host name, and GitHub is used if no forges are guessed.
+*/
+func FindForge(config *Config, host string) Forge {
+	host = strings.ToLower(host)
+	if forges, err := ParseForges(config.GetValue(Forges)); err == nil {
+		if forge, ok := forges[host]; ok {
+			return forge
+		}
+	}
+	if forge, ok := wellKnownForges[host]; ok {
+		return forge
+	}
+	for _, name := range []string{"gitlab", "gitea", "forgejo", "bitbucket"} {
+		if strings.Contains(host, name) {
+			var forge, _ = ParseForge(name)
+			return forge
+		}
+	}
+	return GitHub
+}
+
+/*
+BrowseOption represents the target page of the repository on the forge.
+Path and Line are the file and the line number in the branch (Line is ignored if it is 0).
+Issues and Pulls open the list of the issues and the pull (merge) requests.
+*/
+type BrowseOption struct {
+	Branch string
+	Path   string
+	Line   int
+	Issues bool
+	Pulls  bool
+}
+
+/*
+WebURL returns the url of the top page of the host.
+The scheme and the port are kept only in the http(s) urls, since the others (e.g., the port of ssh) are not for the web.
+*/
+func (r *RemoteURL) WebURL() string {
+	if r.Scheme == "http" || r.Scheme == "https" {
+		if r.Port != "" {
+			return fmt.Sprintf("%s://%s:%s", r.Scheme, r.Host, r.Port)
+		}
+		return fmt.Sprintf("%s://%s", r.Scheme, r.Host)
+	}
+	return "https://" + r.Host
+}
+
+/*
+BrowserURL returns the url of the web page of the repository on the given forge.
+*/
+func (r *RemoteURL) BrowserURL(forge Forge, opts *BrowseOption) (string, error) {
+	if r.Host == "" {
+		return "", fmt.Errorf("%s: not a remote repository on the web", r.Path)
+	}
+	var base = r.WebURL() + "/" + r.Path
+	var layout = forgeLayouts[forge]
+	if layout == nil {
+		return "", fmt.Errorf("%s: unknown forge", forge)
+	}
+	switch {
+	case opts.Issues:
+		return base + layout.issues, nil
+	case opts.Pulls:
+		return base + layout.pulls, nil
+	case opts.Path != "":
+		var url = base + layout.refPath(layout.blob, branchOrHead(opts.Branch)) + "/" + strings.TrimLeft(opts.Path, "/")
+		if opts.Line > 0 {
+			url = url + fmt.Sprintf(layout.line, opts.Line)
+		}
+		return url, nil
+	case opts.Branch != "":
+		return base + layout.refPath(layout.tree, opts.Branch), nil
+	}
+	return base, nil
+}
+
+func branchOrHead(branch string) string {
+	if branch == "" {
+		return "HEAD"
+	}
+	return branch
+}
+
+var commitHashPattern = regexp.MustCompile("^[0-9a-f]{40}$")
+
+/*
+isCommitRef returns true if the given ref points the commit directly, that is, the commit hash of the detached HEAD, or HEAD itself.
+*/
+func isCommitRef(ref string) bool {
+	return ref == "HEAD" || commitHashPattern.MatchString(ref)
+}
+
+type forgeLayout struct {
+	tree   string
+	blob   string
+	commit string
+	line   string
+	issues string
+	pulls  string
+}
+
+/*
+refPath returns the path of the given ref by the given layout (tree or blob).
+The commit layout is used instead if the ref points the commit and the forge distinguishes the branches and the commits (Gitea and Forgejo).
+*/
+func (layout *forgeLayout) refPath(format string, ref string) string {
+	if layout.commit != "" && isCommitRef(ref) {
+		format = layout.commit
+	}
+	return fmt.Sprintf(format, ref)
+}
+
+var forgeLayouts = map[Forge]*forgeLayout{
+	GitHub:    {tree: "/tree/%s", blob: "/blob/%s", line: "#L%d", issues: "/issues", pulls: "/pulls"},
+	GitLab:    {tree: "/-/tree/%s", blob: "/-/blob/%s", line: "#L%d", issues: "/-/issues", pulls: "/-/merge_requests"},
+	Gitea:     {tree: "/src/branch/%s", blob: "/src/branch/%s", commit: "/src/commit/%s", line: "#L%d", issues: "/issues", pulls: "/pulls"},
+	Bitbucket: {tree: "/src/%s", blob: "/src/%s", line: "#lines-%d", issues: "/issues", pulls: "/pull-requests"},
+}
@@ -0,0 +1,88 @@
+package rrh
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFindForge(t *testing.T) {
+	os.Setenv(Forges, "git.example.com=gitlab,code.example.org=forgejo")
+	defer os.Unsetenv(Forges)
+	var testcases = []struct {
+		host string
+		wont Forge
+	}{
+		{"github.com", GitHub},
+		{"gitlab.com", GitLab},
+		{"codeberg.org", Gitea},
+		{"bitbucket.org", Bitbucket},
+		{"git.example.com", GitLab},
+		{"CODE.example.org", Gitea},
+		{"gitlab.acme.com", GitLab},
+		{"unknown.example.com", GitHub},
+	}
+	var config = NewConfig()
+	for _, tc := range testcases {
+		if got := FindForge(config, tc.host); got != tc.wont {
+			t.Errorf("FindForge(%s) did not match, wont: %s, got: %s", tc.host, tc.wont, got)
+		}
+	}
+}
+
+func TestParseForges(t *testing.T) {
+	var testcases = []struct {
+		value    string
+		hasError bool
+		wontSize int
+	}{
+		{"", false, 0},
+		{"git.example.com=gitlab, code.example.org=Forgejo", false, 2},
+		{"git.example.com", true, 0},
+		{"git.example.com=svn", true, 0},
+		{"=gitlab", true, 0},
+	}
+	for _, tc := range testcases {
+		var forges, err = ParseForges(tc.value)
+		if (err != nil) != tc.hasError {
+			t.Errorf("ParseForges(%s) error flag did not match, wont: %v, got: %v", tc.value, tc.hasError, err)
+		}
+		if err == nil && len(forges) != tc.wontSize {
+			t.Errorf("ParseForges(%s) size did not match, wont: %d, got: %d", tc.value, tc.wontSize, len(forges))
+		}
+	}
+}
+
+func TestBrowserURL(t *testing.T) {
+	var testcases = []struct {
+		url   string
+		forge Forge
+		opts  *BrowseOption
+		wont  string
+	}{
+		{"git@github.com:tamada/rrh.git", GitHub, &BrowseOption{}, "https://github.com/tamada/rrh"},
+		{"https://github.com/tamada/rrh.git", GitHub, &BrowseOption{Branch: "main"}, "https://github.com/tamada/rrh/tree/main"},
+		{"ssh://git@github.com/tamada/rrh.git", GitHub, &BrowseOption{Path: "README.md", Line: 10}, "https://github.com/tamada/rrh/blob/HEAD/README.md#L10"},
+		{"git://github.com/tamada/rrh.git", GitHub, &BrowseOption{Pulls: true}, "https://github.com/tamada/rrh/pulls"},
+		{"ssh://git@gitlab.com:2222/acme/group/sub/repo.git", GitLab, &BrowseOption{Branch: "dev", Path: "/src/main.go", Line: 3}, "https://gitlab.com/acme/group/sub/repo/-/blob/dev/src/main.go#L3"},
+		{"https://gitlab.example.com:8443/acme/group/repo.git", GitLab, &BrowseOption{Pulls: true}, "https://gitlab.example.com:8443/acme/group/repo/-/merge_requests"},
+		{"git@codeberg.org:acme/repo.git", Gitea, &BrowseOption{Branch: "main", Path: "go.mod"}, "https://codeberg.org/acme/repo/src/branch/main/go.mod"},
+		{"git@bitbucket.org:acme/repo.git", Bitbucket, &BrowseOption{Branch: "main", Path: "go.mod", Line: 5}, "https://bitbucket.org/acme/repo/src/main/go.mod#lines-5"},
+		{"http://git.example.com/acme/repo", Gitea, &BrowseOption{Issues: true}, "http://git.example.com/acme/repo/issues"},
+		{"git@codeberg.org:acme/repo.git", Gitea, &BrowseOption{Branch: "0123456789abcdef0123456789abcdef01234567", Path: "go.mod", Line: 2}, "https://codeberg.org/acme/repo/src/commit/0123456789abcdef0123456789abcdef01234567/go.mod#L2"},
+		{"git@codeberg.org:acme/repo.git", Gitea, &BrowseOption{Branch: "0123456789abcdef0123456789abcdef01234567"}, "https://codeberg.org/acme/repo/src/commit/0123456789abcdef0123456789abcdef01234567"},
+		{"git@codeberg.org:acme/repo.git", Gitea, &BrowseOption{Path: "go.mod"}, "https://codeberg.org/acme/repo/src/commit/HEAD/go.mod"},
+		{"git@github.com:tamada/rrh.git", GitHub, &BrowseOption{Branch: "0123456789abcdef0123456789abcdef01234567"}, "https://github.com/tamada/rrh/tree/0123456789abcdef0123456789abcdef01234567"},
+	}
+	for _, tc := range testcases {
+		var got, err = ParseRemoteURL(tc.url).BrowserURL(tc.forge, tc.opts)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.url, err.Error())
+		}
+		if got != tc.wont {
+			t.Errorf("%s (%s): browser url did not match, wont: %s, got: %s", tc.url, tc.forge, tc.wont, got)
+		}
+	}
+	if _, err := ParseRemoteURL("/home/user/repos/rrh.git").BrowserURL(GitHub, &BrowseOption{}); err == nil {
+		t.Errorf("the local path should not be converted to the browser url")
+	}
+}
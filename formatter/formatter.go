@@ -0,0 +1,175 @@
+/*
+Package formatter provides the output formats shared among the commands.
+
+The commands build a Table, which is the list of the rows with the columns, and print it by the Formatter of the given format.
+The available formats are `default` (the table without borders and headers), `table`, `csv`, `markdown` (the Markdown table),
+`json` (the array of the objects), `ndjson` (the object in each line), `yaml`, and `template=TEMPLATE`.
+The template is the Go template (text/template) applied to each row, such as `template={{.ID}}\t{{.Path}}`,
+and the fields of the row are referred by the names of the columns.
+
+The values in the rows are string, int, bool, and []string.
+The formats for the humans (default, table, csv, and markdown) print []string by joining with commas,
+and the formats for the machines (json, ndjson, and yaml) print it as the array.
+The commands printing the nested structures in json, ndjson, and yaml build Object, and print it by WriteJSON or WriteYAML.
+*/
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tamada/rrh"
+)
+
+/*
+Column represents the column of the table.
+Name is referred in the templates (e.g., ID), Key is the key in json, ndjson, and yaml (e.g., id),
+and Header is the header of default, table, csv, and markdown (e.g., repository id).
+*/
+type Column struct {
+	Name   string
+	Key    string
+	Header string
+}
+
+/*
+NewColumn creates the column of the given name, key, and header.
+*/
+func NewColumn(name, key, header string) *Column {
+	return &Column{Name: name, Key: key, Header: header}
+}
+
+/*
+Table represents the printing rows.
+Each row has the values of the columns in the same order.
+*/
+type Table struct {
+	Columns []*Column
+	Rows    [][]interface{}
+}
+
+/*
+NewTable creates the empty table of the given columns.
+*/
+func NewTable(columns ...*Column) *Table {
+	return &Table{Columns: columns, Rows: [][]interface{}{}}
+}
+
+/*
+Append appends the row of the given values.
+*/
+func (t *Table) Append(values ...interface{}) {
+	t.Rows = append(t.Rows, values)
+}
+
+/*
+Headers returns the headers of the columns.
+*/
+func (t *Table) Headers() []string {
+	var results = []string{}
+	for _, column := range t.Columns {
+		results = append(results, column.Header)
+	}
+	return results
+}
+
+/*
+Strings returns the values of each row as the strings.
+*/
+func (t *Table) Strings() [][]string {
+	var results = [][]string{}
+	for _, row := range t.Rows {
+		var line = []string{}
+		for _, value := range row {
+			line = append(line, toString(value))
+		}
+		results = append(results, line)
+	}
+	return results
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+/*
+Formatter prints the given table in the specific format.
+*/
+type Formatter interface {
+	Format(w io.Writer, table *Table) error
+}
+
+/*
+Formats represents the names of the available formats.
+*/
+var Formats = []string{"default", "table", "csv", "markdown", "json", "ndjson", "yaml", "template"}
+
+/*
+Parse splits the given format into the name and the argument, such as `template={{.ID}}` into `template` and `{{.ID}}`.
+The name is converted into lower case.
+*/
+func Parse(format string) (string, string) {
+	var items = strings.SplitN(format, "=", 2)
+	var name = strings.ToLower(strings.TrimSpace(items[0]))
+	if len(items) == 1 {
+		return name, ""
+	}
+	return name, items[1]
+}
+
+/*
+Validate validates the given format is in the available formats.
+The template format requires the template as the argument.
+*/
+func Validate(format string, availables []string) error {
+	var name, arg = Parse(format)
+	if !rrh.FindIn(name, availables) {
+		return fmt.Errorf("%s: not available format. availables: %s", format, strings.Join(availables, ", "))
+	}
+	if name == "template" && arg == "" {
+		return fmt.Errorf("%s: template format requires the template, such as template='{{.ID}}'", format)
+	}
+	if name != "template" && arg != "" {
+		return fmt.Errorf("%s: %s format accepts no arguments", format, name)
+	}
+	return nil
+}
+
+/*
+New creates the formatter of the given format.
+The header flag is for table and csv format; default format prints no headers, and markdown format always prints the headers.
+*/
+func New(format string, header bool) (Formatter, error) {
+	if err := Validate(format, Formats); err != nil {
+		return nil, err
+	}
+	var name, arg = Parse(format)
+	switch name {
+	case "default":
+		return &tableFormat{header: false, border: false}, nil
+	case "table":
+		return &tableFormat{header: header, border: true}, nil
+	case "csv":
+		return &csvFormat{header: header}, nil
+	case "markdown":
+		return &markdownFormat{}, nil
+	case "json":
+		return &jsonFormat{}, nil
+	case "ndjson":
+		return &ndjsonFormat{}, nil
+	case "yaml":
+		return &yamlFormat{}, nil
+	case "template":
+		return newTemplateFormat(arg)
+	}
+	panic("never reach this line!")
+}
@@ -0,0 +1,85 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func newTestTable() *Table {
+	var table = NewTable(NewColumn("ID", "id", "repository id"), NewColumn("Path", "path", "repository path"), NewColumn("Tags", "tags", "tags"))
+	table.Append("repo1", "/src/repo1", []string{"lang=go", "archived"})
+	table.Append("repo2", "/src/a|b", []string{})
+	return table
+}
+
+func TestFormat(t *testing.T) {
+	var testcases = []struct {
+		format string
+		header bool
+		wont   string
+	}{
+		{"default", true, "repo1    /src/repo1    lang=go,archived    \nrepo2    /src/a|b                          \n"},
+		{"csv", true, "repository id,repository path,tags\nrepo1,/src/repo1,\"lang=go,archived\"\nrepo2,/src/a|b,\n"},
+		{"CSV", false, "repo1,/src/repo1,\"lang=go,archived\"\nrepo2,/src/a|b,\n"},
+		{"markdown", false, "| repository id | repository path | tags |\n| --- | --- | --- |\n| repo1 | /src/repo1 | lang=go,archived |\n| repo2 | /src/a\\|b |  |\n"},
+		{"json", false, `[{"id":"repo1","path":"/src/repo1","tags":["lang=go","archived"]},{"id":"repo2","path":"/src/a|b","tags":[]}]` + "\n"},
+		{"ndjson", false, `{"id":"repo1","path":"/src/repo1","tags":["lang=go","archived"]}` + "\n" + `{"id":"repo2","path":"/src/a|b","tags":[]}` + "\n"},
+		{"yaml", false, "- id: \"repo1\"\n  path: \"/src/repo1\"\n  tags:\n    - \"lang=go\"\n    - \"archived\"\n- id: \"repo2\"\n  path: \"/src/a|b\"\n  tags: []\n"},
+		{`template={{.ID}}\t{{.Path}}`, false, "repo1\t/src/repo1\nrepo2\t/src/a|b\n"},
+		{`template={{.ID}}: {{join .Tags ";"}}`, false, "repo1: lang=go;archived\nrepo2: \n"},
+	}
+	for _, tc := range testcases {
+		var f, err = New(tc.format, tc.header)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.format, err.Error())
+			continue
+		}
+		var buffer = bytes.NewBuffer([]byte{})
+		if err := f.Format(buffer, newTestTable()); err != nil {
+			t.Errorf("%s: format failed: %s", tc.format, err.Error())
+		}
+		if buffer.String() != tc.wont {
+			t.Errorf("%s: output did not match, wont: %q, got: %q", tc.format, tc.wont, buffer.String())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var testcases = []struct {
+		format   string
+		hasError bool
+	}{
+		{"json", false},
+		{"YAML", false},
+		{"template={{.ID}}", false},
+		{"template", true},
+		{"template={{.ID", true},
+		{"json=pretty", true},
+		{"xml", true},
+	}
+	for _, tc := range testcases {
+		var _, err = New(tc.format, false)
+		if (err != nil) != tc.hasError {
+			t.Errorf("%s: error flag did not match, wont: %v, got: %v", tc.format, tc.hasError, err)
+		}
+	}
+}
+
+func TestEmptyTable(t *testing.T) {
+	var testcases = []struct {
+		format string
+		wont   string
+	}{
+		{"json", "[]\n"},
+		{"ndjson", ""},
+		{"yaml", "[]\n"},
+	}
+	for _, tc := range testcases {
+		var f, _ = New(tc.format, false)
+		var buffer = bytes.NewBuffer([]byte{})
+		f.Format(buffer, NewTable(NewColumn("ID", "id", "id")))
+		if buffer.String() != tc.wont {
+			t.Errorf("%s: output did not match, wont: %q, got: %q", tc.format, tc.wont, buffer.String())
+		}
+	}
+}
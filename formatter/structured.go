@@ -0,0 +1,252 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+/*
+Object represents the JSON object (or the YAML mapping) keeping the order of the keys.
+The values are string, int, bool, []string, Object, and []Object.
+The commands printing the nested structures (e.g., the groups and their repositories) build Object instead of Table.
+*/
+type Object []*Field
+
+/*
+Field represents the entry of Object.
+*/
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+/*
+Put returns the object appended the given key and value.
+*/
+func (o Object) Put(key string, value interface{}) Object {
+	return append(o, &Field{Key: key, Value: value})
+}
+
+/*
+MarshalJSON marshals the object in the order of the keys.
+*/
+func (o Object) MarshalJSON() ([]byte, error) {
+	var items = []string{}
+	for _, field := range o {
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(normalize(field.Value))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, string(key)+":"+string(value))
+	}
+	return []byte("{" + strings.Join(items, ",") + "}"), nil
+}
+
+/*
+Objects returns the rows of the table as the objects, whose keys are the keys of the columns.
+*/
+func (t *Table) Objects() []Object {
+	var results = []Object{}
+	for _, row := range t.Rows {
+		var object = Object{}
+		for index, column := range t.Columns {
+			object = object.Put(column.Key, row[index])
+		}
+		results = append(results, object)
+	}
+	return results
+}
+
+/*
+normalize converts nil slices into the empty slices, for printing them as the empty arrays.
+*/
+func normalize(value interface{}) interface{} {
+	switch values := value.(type) {
+	case []string:
+		if values == nil {
+			return []string{}
+		}
+	case []Object:
+		if values == nil {
+			return []Object{}
+		}
+	}
+	return value
+}
+
+type jsonFormat struct {
+}
+
+func (jf *jsonFormat) Format(w io.Writer, t *Table) error {
+	return WriteJSON(w, t.Objects())
+}
+
+/*
+WriteJSON writes the given value in JSON with the trailing newline.
+*/
+func WriteJSON(w io.Writer, value interface{}) error {
+	var data, err = json.Marshal(normalize(value))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+type ndjsonFormat struct {
+}
+
+func (nf *ndjsonFormat) Format(w io.Writer, t *Table) error {
+	for _, object := range t.Objects() {
+		if err := WriteJSON(w, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+yamlFormat prints the table as the sequence of the mappings.
+The strings are printed in the double quoted style, which is compatible with the JSON strings.
+*/
+type yamlFormat struct {
+}
+
+func (yf *yamlFormat) Format(w io.Writer, t *Table) error {
+	var objects = t.Objects()
+	if len(objects) == 0 {
+		_, err := io.WriteString(w, "[]\n")
+		return err
+	}
+	for _, object := range objects {
+		if err := writeYamlObject(w, object, "- ", "  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+WriteYAML writes the given object as the YAML mapping.
+*/
+func WriteYAML(w io.Writer, object Object) error {
+	return writeYamlObject(w, object, "", "")
+}
+
+/*
+writeYamlObject writes the entries of the mapping.
+The first entry is written after the given prefix (e.g., "- " of the sequence), and the rest entries are written after the given indent.
+*/
+func writeYamlObject(w io.Writer, object Object, prefix, indent string) error {
+	if len(object) == 0 {
+		_, err := fmt.Fprintf(w, "%s{}\n", prefix)
+		return err
+	}
+	for index, field := range object {
+		var head = indent
+		if index == 0 {
+			head = prefix
+		}
+		if err := writeYamlEntry(w, head, indent, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+writeYamlEntry writes the entry of the mapping.
+The keys are written as is, since they are the plain identifiers (e.g., last-accessed) given by the commands.
+*/
+func writeYamlEntry(w io.Writer, prefix, indent string, field *Field) error {
+	switch values := normalize(field.Value).(type) {
+	case []string:
+		if len(values) == 0 {
+			_, err := fmt.Fprintf(w, "%s%s: []\n", prefix, field.Key)
+			return err
+		}
+		fmt.Fprintf(w, "%s%s:\n", prefix, field.Key)
+		for _, item := range values {
+			var itemString, err = yamlScalar(item)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s  - %s\n", indent, itemString)
+		}
+		return nil
+	case []Object:
+		if len(values) == 0 {
+			_, err := fmt.Fprintf(w, "%s%s: []\n", prefix, field.Key)
+			return err
+		}
+		fmt.Fprintf(w, "%s%s:\n", prefix, field.Key)
+		for _, item := range values {
+			if err := writeYamlObject(w, item, indent+"  - ", indent+"    "); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Object:
+		if len(values) == 0 {
+			_, err := fmt.Fprintf(w, "%s%s: {}\n", prefix, field.Key)
+			return err
+		}
+		fmt.Fprintf(w, "%s%s:\n", prefix, field.Key)
+		return writeYamlObject(w, values, indent+"  ", indent+"  ")
+	}
+	var valueString, err = yamlScalar(field.Value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s: %s\n", prefix, field.Key, valueString)
+	return err
+}
+
+func yamlScalar(value interface{}) (string, error) {
+	var data, err = json.Marshal(value)
+	return string(data), err
+}
+
+type templateFormat struct {
+	template *template.Template
+}
+
+/*
+templateUnescaper converts the escape sequences in the templates, since the shells pass them as is (e.g., '{{.ID}}\t{{.Path}}').
+*/
+var templateUnescaper = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n")
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+func newTemplateFormat(text string) (*templateFormat, error) {
+	var t, err = template.New("format").Funcs(templateFuncs).Option("missingkey=error").Parse(templateUnescaper.Replace(text))
+	if err != nil {
+		return nil, err
+	}
+	return &templateFormat{template: t}, nil
+}
+
+func (tf *templateFormat) Format(w io.Writer, t *Table) error {
+	for _, row := range t.Rows {
+		var data = map[string]interface{}{}
+		for index, column := range t.Columns {
+			data[column.Name] = normalize(row[index])
+		}
+		if err := tf.template.Execute(w, data); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
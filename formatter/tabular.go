@@ -0,0 +1,71 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type tableFormat struct {
+	header bool
+	border bool
+}
+
+func (tf *tableFormat) Format(w io.Writer, t *Table) error {
+	table := tablewriter.NewWriter(w)
+	if tf.header {
+		table.SetHeader(t.Headers())
+	}
+	table.SetBorder(tf.border)
+	if !tf.border {
+		table.SetNoWhiteSpace(true)
+		table.SetTablePadding("    ")
+	}
+	table.AppendBulk(t.Strings())
+	table.Render()
+	return nil
+}
+
+type csvFormat struct {
+	header bool
+}
+
+func (cf *csvFormat) Format(w io.Writer, t *Table) error {
+	writer := csv.NewWriter(w)
+	if cf.header {
+		writer.Write(t.Headers())
+	}
+	for _, line := range t.Strings() {
+		writer.Write(line)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type markdownFormat struct {
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+func (mf *markdownFormat) Format(w io.Writer, t *Table) error {
+	var separators = []string{}
+	for range t.Columns {
+		separators = append(separators, "---")
+	}
+	writeMarkdownRow(w, t.Headers())
+	writeMarkdownRow(w, separators)
+	for _, line := range t.Strings() {
+		writeMarkdownRow(w, line)
+	}
+	return nil
+}
+
+func writeMarkdownRow(w io.Writer, items []string) {
+	var escaped = []string{}
+	for _, item := range items {
+		escaped = append(escaped, markdownEscaper.Replace(item))
+	}
+	io.WriteString(w, "| "+strings.Join(escaped, " | ")+" |\n")
+}
@@ -0,0 +1,49 @@
+package rrh
+
+import (
+	"strings"
+	"time"
+)
+
+/*
+Frecency returns the score of the repository by the frequency and the recency of the accesses.
+The score is the access count weighted by the elapsed time from the last access,
+that is, 4 (within an hour), 2 (within a day), 0.5 (within a week), and 0.25 (otherwise).
+The repositories never accessed have zero score.
+*/
+func (repo *Repository) Frecency(now time.Time) float64 {
+	if repo.LastAccessed == nil {
+		return 0
+	}
+	var count = MaxInt(repo.AccessCount, 1)
+	var elapsed = now.Sub(repo.LastAccessed.Time())
+	switch {
+	case elapsed < time.Hour:
+		return float64(count) * 4
+	case elapsed < 24*time.Hour:
+		return float64(count) * 2
+	case elapsed < 7*24*time.Hour:
+		return float64(count) * 0.5
+	default:
+		return float64(count) * 0.25
+	}
+}
+
+/*
+FuzzyMatch returns true if the given target contains all of the characters of the pattern in order (case insensitive).
+For example, "rh" matches "rrh", and "tmdrrh" matches "tamada/rrh".
+*/
+func FuzzyMatch(pattern string, target string) bool {
+	var runes = []rune(strings.ToLower(target))
+	var index = 0
+	for _, c := range strings.ToLower(pattern) {
+		for index < len(runes) && runes[index] != c {
+			index++
+		}
+		if index >= len(runes) {
+			return false
+		}
+		index++
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package rrh
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrecency(t *testing.T) {
+	var now = time.Unix(1000000000, 0)
+	var at = func(elapsed time.Duration) *RrhTime {
+		var rt = RrhTime{now.Add(-elapsed)}
+		return &rt
+	}
+	var testcases = []struct {
+		lastAccessed *RrhTime
+		count        int
+		wontScore    float64
+	}{
+		{nil, 0, 0},
+		{at(time.Minute), 0, 4},
+		{at(time.Minute), 3, 12},
+		{at(2 * time.Hour), 3, 6},
+		{at(3 * 24 * time.Hour), 4, 2},
+		{at(30 * 24 * time.Hour), 4, 1},
+	}
+	for _, tc := range testcases {
+		var repo = &Repository{ID: "repo", LastAccessed: tc.lastAccessed, AccessCount: tc.count}
+		if score := repo.Frecency(now); score != tc.wontScore {
+			t.Errorf("frecency of %v (count %d) did not match, wont: %f, got: %f", tc.lastAccessed, tc.count, tc.wontScore, score)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	var testcases = []struct {
+		pattern string
+		target  string
+		wont    bool
+	}{
+		{"rh", "rrh", true},
+		{"tmdrrh", "github.com/tamada/rrh", true},
+		{"RRH", "rrh", true},
+		{"", "rrh", true},
+		{"hrr", "rrh", false},
+		{"rrhh", "rrh", false},
+	}
+	for _, tc := range testcases {
+		if got := FuzzyMatch(tc.pattern, tc.target); got != tc.wont {
+			t.Errorf("FuzzyMatch(%s, %s) did not match, wont: %v, got: %v", tc.pattern, tc.target, tc.wont, got)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"gopkg.in/src-d/go-git.v4"
@@ -188,6 +189,34 @@ func (status *StatusOption) StatusOfRepository(db *Database, name *Relation) ([]
 	return results, nil
 }
 
+/*
+IsGitRepository returns true if the given path is the git repository.
+*/
+func IsGitRepository(path string) bool {
+	var _, err = openGitRepository(path)
+	return err == nil
+}
+
+/*
+FindGitRoot returns the root directory of the git working tree containing the given path,
+by walking up the directories to find `.git` (the directory, or the file for the submodules and the worktrees).
+This function does not open the git repository, for the fast detection.
+*/
+func FindGitRoot(path string) (string, error) {
+	var absPath, err = filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for dir := absPath; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", fmt.Errorf("%s: not in the git repository", path)
+		}
+	}
+}
+
 /*
 FindRemotes function returns the remote of the given git repository.
 */
@@ -205,5 +234,66 @@ func FindRemotes(path string) ([]*Remote, error) {
 		var config = remote.Config()
 		crs = append(crs, &Remote{Name: config.Name, URL: config.URLs[0]})
 	}
+	sort.Slice(crs, func(i, j int) bool { return crs[i].Name < crs[j].Name })
 	return crs, nil
 }
+
+/*
+IsDirty returns true if the given repository has the staged or the unstaged changes of the tracked files.
+The untracked files are not considered.
+*/
+func IsDirty(repo *Repository) (bool, error) {
+	var r, err = openGitRepository(repo.Path)
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", repo.ID, err.Error())
+	}
+	var status, err2 = findStatus(r)
+	if err2 != nil {
+		return false, fmt.Errorf("%s: %s", repo.ID, err2.Error())
+	}
+	for _, s := range status {
+		if checkUpdateFlag(s.Staging) || checkUpdateFlag(s.Worktree) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+/*
+CurrentBranch returns the name of the current branch of the given git repository.
+If the HEAD is detached, this function returns the hash of the HEAD.
+*/
+func CurrentBranch(path string) (string, error) {
+	var repo, err = openGitRepository(path)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	if head.Name().IsBranch() {
+		return head.Name().Short(), nil
+	}
+	return head.Hash().String(), nil
+}
+
+/*
+LastCommitTime returns the committed time of the HEAD of the given git repository.
+*/
+func LastCommitTime(path string) (*time.Time, error) {
+	var repo, err = openGitRepository(path)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	var when = commit.Committer.When
+	return &when, nil
+}
@@ -1,6 +1,9 @@
 package rrh
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -50,3 +53,30 @@ func TestFindRemotes(t *testing.T) {
 		}
 	}
 }
+
+func TestFindGitRoot(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-gitroot")
+	defer os.RemoveAll(dir)
+	var root = filepath.Join(dir, "repo")
+	initGitRepository(t, root, "https://github.com/example/repo.git")
+	os.MkdirAll(filepath.Join(root, "sub", "dir"), 0755)
+
+	var testcases = []struct {
+		path      string
+		errorFlag bool
+		wontRoot  string
+	}{
+		{root, false, root},
+		{filepath.Join(root, "sub", "dir"), false, root},
+		{dir, true, ""},
+	}
+	for _, tc := range testcases {
+		var got, err = FindGitRoot(tc.path)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("%s: error flag did not match, wont: %v, got: %v", tc.path, tc.errorFlag, err)
+		}
+		if got != tc.wontRoot {
+			t.Errorf("%s: git root did not match, wont: %s, got: %s", tc.path, tc.wontRoot, got)
+		}
+	}
+}
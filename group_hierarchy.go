@@ -0,0 +1,168 @@
+package rrh
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+GroupPathSeparator is the separator of the group names in the path of the group hierarchy (e.g., `work/payments/backend`).
+*/
+const GroupPathSeparator = "/"
+
+/*
+IsRoot returns true if the group has no parent group.
+*/
+func (g *Group) IsRoot() bool {
+	return g.Parent == ""
+}
+
+/*
+FindChildGroups returns the direct child groups of the given group.
+If the given groupID is empty, this method returns the root groups.
+*/
+func (db *Database) FindChildGroups(groupID string) []*Group {
+	var children = []*Group{}
+	for _, group := range db.Groups {
+		if group.Parent == groupID {
+			children = append(children, group)
+		}
+	}
+	return children
+}
+
+/*
+FindDescendantGroups returns the names of all descendant groups of the given group in the depth-first order.
+The result does not contain the given group itself.
+*/
+func (db *Database) FindDescendantGroups(groupID string) []string {
+	return findDescendantGroupsImpl(db, groupID, []string{groupID})
+}
+
+func findDescendantGroupsImpl(db *Database, groupID string, visited []string) []string {
+	var results = []string{}
+	for _, child := range db.FindChildGroups(groupID) {
+		// the broken database may have circular hierarchies.
+		if FindIn(child.Name, visited) || FindIn(child.Name, results) {
+			continue
+		}
+		results = append(results, child.Name)
+		results = append(results, findDescendantGroupsImpl(db, child.Name, append(append([]string{}, visited...), results...))...)
+	}
+	return results
+}
+
+/*
+FindAncestorGroups returns the names of the ancestor groups of the given group from the parent to the root.
+*/
+func (db *Database) FindAncestorGroups(groupID string) []string {
+	var results = []string{}
+	var group = db.FindGroup(groupID)
+	for group != nil && !group.IsRoot() && !FindIn(group.Parent, results) && group.Parent != groupID {
+		results = append(results, group.Parent)
+		group = db.FindGroup(group.Parent)
+	}
+	return results
+}
+
+/*
+GroupPath returns the path of the given group from the root group, such as `work/payments/backend`.
+*/
+func (db *Database) GroupPath(groupID string) string {
+	var names = []string{groupID}
+	for _, ancestor := range db.FindAncestorGroups(groupID) {
+		names = append([]string{ancestor}, names...)
+	}
+	return strings.Join(names, GroupPathSeparator)
+}
+
+/*
+SetParentGroup sets the parent of the group of groupID to the group of parentID.
+The empty parentID makes the group a root group.
+This method fails if the given parent is the group itself or its descendants.
+*/
+func (db *Database) SetParentGroup(groupID string, parentID string) error {
+	var group = db.FindGroup(groupID)
+	if group == nil {
+		return fmt.Errorf("%s: group not found", groupID)
+	}
+	if parentID != "" {
+		if !db.HasGroup(parentID) {
+			return fmt.Errorf("%s: parent group not found", parentID)
+		}
+		if parentID == groupID || FindIn(parentID, db.FindDescendantGroups(groupID)) {
+			return fmt.Errorf("%s: could not be the parent of %s (circular hierarchy)", parentID, groupID)
+		}
+	}
+	group.Parent = parentID
+	return nil
+}
+
+/*
+FindRelationsOfGroupRecursively returns the repository ids belonging to the given group and its descendant groups.
+The result has no duplicated ids.
+*/
+func (db *Database) FindRelationsOfGroupRecursively(groupID string) []string {
+	var results = []string{}
+	var groups = append([]string{groupID}, db.FindDescendantGroups(groupID)...)
+	for _, group := range groups {
+		for _, repoID := range db.FindRelationsOfGroup(group) {
+			if !FindIn(repoID, results) {
+				results = append(results, repoID)
+			}
+		}
+	}
+	return results
+}
+
+/*
+ContainsCountRecursively returns the number of repositories in the given group and its descendant groups.
+*/
+func (db *Database) ContainsCountRecursively(groupID string) int {
+	return len(db.FindRelationsOfGroupRecursively(groupID))
+}
+
+/*
+ExpandGroupNames returns the given group names and their descendant group names without duplication.
+*/
+func (db *Database) ExpandGroupNames(groupNames []string) []string {
+	var results = []string{}
+	for _, groupName := range groupNames {
+		var names = append([]string{groupName}, db.FindDescendantGroups(groupName)...)
+		for _, name := range names {
+			if !FindIn(name, results) {
+				results = append(results, name)
+			}
+		}
+	}
+	return results
+}
+
+func reparentChildren(db *Database, groupID string, newParent string) {
+	for _, child := range db.FindChildGroups(groupID) {
+		child.Parent = newParent
+	}
+}
+
+/*
+DeleteGroupRecursively removes the given group and its descendant groups from DB.
+If forceFlag is false and some groups in the subtree have repositories, the function fails to remove.
+*/
+func (db *Database) DeleteGroupRecursively(groupID string, forceFlag bool) error {
+	if !db.HasGroup(groupID) {
+		return fmt.Errorf("%s: group not found", groupID)
+	}
+	var groups = append(db.FindDescendantGroups(groupID), groupID)
+	var count = 0
+	for _, group := range groups {
+		count = count + countStaticRelations(db, group)
+	}
+	if !forceFlag && count != 0 {
+		return fmt.Errorf("%s: group subtree has %d relations", groupID, count)
+	}
+	for _, group := range groups {
+		db.UnrelateFromGroup(group)
+		deleteGroup(db, group)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package rrh
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func buildHierarchy(db *Database) {
+	db.CreateGroup("work", "", false)
+	db.CreateGroup("payments", "", false)
+	db.CreateGroup("backend", "", false)
+	db.SetParentGroup("payments", "work")
+	db.SetParentGroup("backend", "payments")
+	db.SetParentGroup("group2", "work")
+	db.Relate("backend", "repo1")
+	db.Relate("payments", "repo2")
+}
+
+func TestGroupHierarchy(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		buildHierarchy(db)
+		if path := db.GroupPath("backend"); path != "work/payments/backend" {
+			t.Errorf("group path did not match, wont: work/payments/backend, got: %s", path)
+		}
+		if descendants := strings.Join(db.FindDescendantGroups("work"), ","); descendants != "group2,payments,backend" {
+			t.Errorf("descendants did not match, wont: group2,payments,backend, got: %s", descendants)
+		}
+		if ancestors := strings.Join(db.FindAncestorGroups("backend"), ","); ancestors != "payments,work" {
+			t.Errorf("ancestors did not match, wont: payments,work, got: %s", ancestors)
+		}
+		if count := db.ContainsCountRecursively("work"); count != 2 {
+			t.Errorf("rolled up count did not match, wont: 2, got: %d", count)
+		}
+		if count := db.ContainsCountRecursively("backend"); count != 1 {
+			t.Errorf("rolled up count did not match, wont: 1, got: %d", count)
+		}
+		if rels := FindTargets(db, []string{"payments"}); len(rels) != 2 {
+			t.Errorf("FindTargets(payments) should include the descendant relations, got: %v", rels)
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestSetParentGroup(t *testing.T) {
+	var testcases = []struct {
+		groupName  string
+		parentName string
+		errorFlag  bool
+	}{
+		{"work", "backend", true},
+		{"work", "work", true},
+		{"work", "unknown", true},
+		{"unknown", "work", true},
+		{"backend", "work", false},
+		{"backend", "", false},
+	}
+	for _, tc := range testcases {
+		var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+			buildHierarchy(db)
+			var err = db.SetParentGroup(tc.groupName, tc.parentName)
+			if (err != nil) != tc.errorFlag {
+				t.Errorf("SetParentGroup(%s, %s) wont error %v, but got %v", tc.groupName, tc.parentName, tc.errorFlag, err)
+			}
+			if err == nil && db.FindGroup(tc.groupName).Parent != tc.parentName {
+				t.Errorf("SetParentGroup(%s, %s) did not update the parent", tc.groupName, tc.parentName)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestDeleteGroupInHierarchy(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		buildHierarchy(db)
+		if err := db.DeleteGroup("group2"); err != nil {
+			t.Errorf("group2 should be deleted: %s", err.Error())
+		}
+		db.Unrelate("payments", "repo2")
+		if err := db.DeleteGroup("payments"); err == nil {
+			t.Errorf("payments has child group, however it was deleted")
+		}
+		if err := db.ForceDeleteGroup("payments"); err != nil {
+			t.Errorf("payments should be deleted forcely: %s", err.Error())
+		}
+		if parent := db.FindGroup("backend").Parent; parent != "work" {
+			t.Errorf("the child of removed group should be moved to its parent, wont: work, got: %s", parent)
+		}
+		if err := db.DeleteGroupRecursively("work", false); err == nil {
+			t.Errorf("work subtree has relations, however it was deleted")
+		}
+		if err := db.DeleteGroupRecursively("work", true); err != nil {
+			t.Errorf("work subtree should be deleted forcely: %s", err.Error())
+		}
+		if db.HasGroup("work") || db.HasGroup("backend") || db.HasRelation("backend", "repo1") {
+			t.Errorf("work subtree still remains")
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestUpdateGroupRenamesParent(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		buildHierarchy(db)
+		db.UpdateGroup("work", &Group{Name: "office"})
+		if parent := db.FindGroup("payments").Parent; parent != "office" {
+			t.Errorf("parent was not renamed, wont: office, got: %s", parent)
+		}
+		db.StoreAndClose()
+		var db2, _ = Open(config)
+		if path := db2.GroupPath("backend"); path != "office/payments/backend" {
+			t.Errorf("stored group path did not match, wont: office/payments/backend, got: %s", path)
+		}
+	})
+	defer os.Remove(dbFile)
+}
@@ -0,0 +1,263 @@
+package rrh
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tamada/rrh/common"
+)
+
+/*
+GroupRule represents a rule for deciding the members of the dynamic group.
+The string form of the rule is `FIELD:PATTERN`, and the available fields are as follows.
+
+	remote:  matches the remote url in the form of `HOST/OWNER/REPO` with the glob pattern (e.g., `remote:github.com/acme/*`).
+	owner:   matches the owner of the remote url with the glob pattern (e.g., `owner:acme`).
+	path:    matches the repository located in the given directory, or the path matching the given glob pattern (e.g., `path:~/src/work`).
+	tag:     matches the repository satisfying the given tag selector (e.g., `tag:lang=go`).
+	id:      matches the repository id with the glob pattern (e.g., `id:rrh*`).
+	file:    matches the repository having the file matching the glob pattern in its top directory (e.g., `file:go.mod`).
+*/
+type GroupRule struct {
+	Field   string
+	Pattern string
+}
+
+var availableRuleFields = []string{"remote", "owner", "path", "tag", "id", "file"}
+
+func (rule *GroupRule) String() string {
+	return rule.Field + ":" + rule.Pattern
+}
+
+/*
+ParseGroupRule parses the given string in the form of `FIELD:PATTERN` to GroupRule.
+*/
+func ParseGroupRule(str string) (*GroupRule, error) {
+	var items = strings.SplitN(strings.TrimSpace(str), ":", 2)
+	if len(items) != 2 || items[1] == "" {
+		return nil, fmt.Errorf("%s: invalid rule, the rule must be FIELD:PATTERN form", str)
+	}
+	var rule = &GroupRule{Field: strings.ToLower(items[0]), Pattern: items[1]}
+	if !FindIn(rule.Field, availableRuleFields) {
+		return nil, fmt.Errorf("%s: unknown rule field (available: %s)", items[0], strings.Join(availableRuleFields, ", "))
+	}
+	return rule, rule.validate()
+}
+
+func (rule *GroupRule) validate() error {
+	var err error
+	switch rule.Field {
+	case "tag":
+		err = ValidateTagSelectors([]string{rule.Pattern})
+	case "path", "file":
+		_, err = filepath.Match(rule.Pattern, "")
+	default:
+		_, err = path.Match(rule.Pattern, "")
+	}
+	if err != nil {
+		return fmt.Errorf("%s: invalid pattern (%s)", rule.String(), err.Error())
+	}
+	return nil
+}
+
+/*
+Match returns true if the given repository satisfies the rule.
+*/
+func (rule *GroupRule) Match(repo *Repository) bool {
+	switch rule.Field {
+	case "remote":
+		return rule.matchRemote(repo)
+	case "owner":
+		return rule.matchOwner(repo)
+	case "path":
+		return matchPath(expandHome(rule.Pattern), repo.Path)
+	case "tag":
+		return repo.MatchTags([]string{rule.Pattern})
+	case "id":
+		var matched, _ = path.Match(rule.Pattern, repo.ID)
+		return matched
+	case "file":
+		return repo.hasFile(rule.Pattern)
+	}
+	return false
+}
+
+/*
+hasFile returns true if the repository has the file matching the given glob pattern in its top directory.
+The result is cached in the repository, since the filesystem is not changed in running a command.
+*/
+func (r *Repository) hasFile(pattern string) bool {
+	var key = r.Path + string(filepath.ListSeparator) + pattern
+	if found, ok := r.fileMatches[key]; ok {
+		return found
+	}
+	var matches, _ = filepath.Glob(filepath.Join(r.Path, pattern))
+	if r.fileMatches == nil {
+		r.fileMatches = map[string]bool{}
+	}
+	r.fileMatches[key] = len(matches) > 0
+	return len(matches) > 0
+}
+
+func (rule *GroupRule) matchRemote(repo *Repository) bool {
+	for _, remote := range repo.Remotes {
+		if matched, _ := path.Match(rule.Pattern, ParseRemoteURL(remote.URL).String()); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *GroupRule) matchOwner(repo *Repository) bool {
+	for _, remote := range repo.Remotes {
+		if matched, _ := path.Match(rule.Pattern, ParseRemoteURL(remote.URL).Owner()); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(pattern string, repoPath string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		var matched, _ = filepath.Match(pattern, repoPath)
+		return matched
+	}
+	var dir = filepath.Clean(pattern)
+	return repoPath == dir || strings.HasPrefix(repoPath, dir+string(filepath.Separator))
+}
+
+/*
+IsDynamic returns true if the members of the group are decided by the rules.
+*/
+func (g *Group) IsDynamic() bool {
+	return len(g.Rules) > 0
+}
+
+/*
+MatchRules returns true if the given repository satisfies all of the rules of the group.
+The static groups (no rules) match no repositories.
+*/
+func (g *Group) MatchRules(repo *Repository) bool {
+	if !g.IsDynamic() {
+		return false
+	}
+	var rules, ok = g.parsedRules()
+	if !ok {
+		return false
+	}
+	for _, rule := range rules {
+		if !rule.Match(repo) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+parsedRules returns the parsed rules of the group, and false if any rule is invalid.
+The rules are parsed once, and parsed again only if the rules of the group are changed.
+*/
+func (g *Group) parsedRules() ([]*GroupRule, bool) {
+	if g.parsed != nil && sameStrings(g.parsed.sources, g.Rules) {
+		return g.parsed.rules, g.parsed.valid
+	}
+	var parsed = &parsedRules{sources: append([]string{}, g.Rules...), valid: true}
+	for _, str := range g.Rules {
+		var rule, err = ParseGroupRule(str)
+		if err != nil {
+			parsed.valid = false
+			break
+		}
+		parsed.rules = append(parsed.rules, rule)
+	}
+	g.parsed = parsed
+	return parsed.rules, parsed.valid
+}
+
+type parsedRules struct {
+	sources []string
+	rules   []*GroupRule
+	valid   bool
+}
+
+func sameStrings(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *Database) isDynamicGroup(groupID string) bool {
+	var group = db.FindGroup(groupID)
+	return group != nil && group.IsDynamic()
+}
+
+func findDynamicMembers(db *Database, group *Group) []string {
+	var results = []string{}
+	for _, repo := range db.Repositories {
+		if group.MatchRules(repo) {
+			results = append(results, repo.ID)
+		}
+	}
+	return results
+}
+
+func findDynamicGroupsOf(db *Database, repo *Repository) []string {
+	var results = []string{}
+	for _, group := range db.Groups {
+		if group.MatchRules(repo) {
+			results = append(results, group.Name)
+		}
+	}
+	return results
+}
+
+/*
+SetGroupRules sets the rules to the group of groupID.
+The group with rules becomes the dynamic group, and the empty rules makes the group static.
+This method fails if the rules are invalid, or the group has the relations with repositories.
+*/
+func (db *Database) SetGroupRules(groupID string, rules []string) error {
+	var group = db.FindGroup(groupID)
+	if group == nil {
+		return fmt.Errorf("%s: group not found", groupID)
+	}
+	var el = common.NewErrorList()
+	var results = []string{}
+	for _, str := range rules {
+		if strings.TrimSpace(str) == "" {
+			continue
+		}
+		var rule, err = ParseGroupRule(str)
+		if err != nil {
+			el = el.Append(err)
+			continue
+		}
+		results = append(results, rule.String())
+	}
+	if el.IsErr() {
+		return el
+	}
+	if count := countStaticRelations(db, groupID); len(results) > 0 && count > 0 {
+		return fmt.Errorf("%s: group has %d relations, could not be the dynamic group", groupID, count)
+	}
+	group.Rules = results
+	return nil
+}
+
+func countStaticRelations(db *Database, groupID string) int {
+	var count = 0
+	for _, relation := range db.Relations {
+		if relation.GroupName == groupID {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,144 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGroupRule(t *testing.T) {
+	var testcases = []struct {
+		giveString string
+		errorFlag  bool
+		wontString string
+	}{
+		{"remote:github.com/acme/*", false, "remote:github.com/acme/*"},
+		{"Tag:lang=go", false, "tag:lang=go"},
+		{"path:~/src/work", false, "path:~/src/work"},
+		{"owner:acme", false, "owner:acme"},
+		{"file:go.mod", false, "file:go.mod"},
+		{"id:repo[", true, ""},
+		{"tag:=go", true, ""},
+		{"unknown:value", true, ""},
+		{"remote", true, ""},
+		{"remote:", true, ""},
+	}
+	for _, tc := range testcases {
+		var rule, err = ParseGroupRule(tc.giveString)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("ParseGroupRule(%s) wont error %v, but got %v", tc.giveString, tc.errorFlag, err)
+		}
+		if err == nil && rule.String() != tc.wontString {
+			t.Errorf("ParseGroupRule(%s) did not match, wont: %s, got: %s", tc.giveString, tc.wontString, rule.String())
+		}
+	}
+}
+
+func TestMatchGroupRules(t *testing.T) {
+	var home, _ = os.UserHomeDir()
+	var repo = &Repository{ID: "rrh", Path: filepath.Join(home, "src", "work", "rrh"),
+		Remotes: []*Remote{{Name: "origin", URL: "git@github.com:acme/rrh.git"}}, Tags: []*Tag{{"lang", "go"}}}
+	var testcases = []struct {
+		rules    []string
+		wontFlag bool
+	}{
+		{[]string{}, false},
+		{[]string{"remote:github.com/acme/*"}, true},
+		{[]string{"remote:github.com/tamada/*"}, false},
+		{[]string{"path:~/src/work"}, true},
+		{[]string{"path:~/src/wor"}, false},
+		{[]string{"path:${HOME}/src/*/rrh"}, true},
+		{[]string{"tag:lang=go", "id:rr*"}, true},
+		{[]string{"tag:lang=go", "id:hoge"}, false},
+		{[]string{"owner:ac*"}, true},
+		{[]string{"owner:tamada"}, false},
+	}
+	for _, tc := range testcases {
+		var group = &Group{Name: "dynamic", Rules: tc.rules}
+		if flag := group.MatchRules(repo); flag != tc.wontFlag {
+			t.Errorf("MatchRules(%v) wont %v, but got %v", tc.rules, tc.wontFlag, flag)
+		}
+	}
+}
+
+func TestDynamicGroup(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		db.CreateGroup("github", "", false)
+		if err := db.SetGroupRules("github", []string{"remote:github.com/example/*"}); err != nil {
+			t.Errorf("SetGroupRules failed: %s", err.Error())
+		}
+		if err := db.SetGroupRules("group1", []string{"id:repo*"}); err == nil {
+			t.Errorf("the group having relations should not be the dynamic group")
+		}
+		if members := strings.Join(db.FindRelationsOfGroup("github"), ","); members != "repo2" {
+			t.Errorf("members of the dynamic group did not match, wont: repo2, got: %s", members)
+		}
+		if groups := strings.Join(db.FindRelationsOfRepository("repo2"), ","); groups != "group3,github" {
+			t.Errorf("groups of repo2 did not match, wont: group3,github, got: %s", groups)
+		}
+		if !db.HasRelation("github", "repo2") || db.ContainsCount("github") != 1 {
+			t.Errorf("github group should contain repo2")
+		}
+		if rels := FindTargets(db, []string{"github"}); len(rels) != 1 || rels[0].RepositoryID != "repo2" {
+			t.Errorf("FindTargets(github) did not match, got: %v", rels)
+		}
+		if err := db.Relate("github", "repo1"); err == nil {
+			t.Errorf("Relate to the dynamic group should fail")
+		}
+		if err := db.Unrelate("github", "repo2"); err == nil {
+			t.Errorf("Unrelate from the dynamic group should fail")
+		}
+		if _, groups := db.Prune(); len(groups) != 1 || groups[0].Name != "group2" {
+			t.Errorf("dynamic group should not be pruned, got: %v", groups)
+		}
+		if err := db.DeleteGroup("github"); err != nil {
+			t.Errorf("dynamic group should be deleted: %s", err.Error())
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestMatchFileRule(t *testing.T) {
+	var repo = &Repository{ID: "rrh", Path: "."}
+	var testcases = []struct {
+		rule     string
+		wontFlag bool
+	}{
+		{"file:group_rule.go", true},
+		{"file:*.go", true},
+		{"file:go.sum", true},
+		{"file:Cargo.toml", false},
+	}
+	for _, tc := range testcases {
+		var rule, _ = ParseGroupRule(tc.rule)
+		if flag := rule.Match(repo); flag != tc.wontFlag {
+			t.Errorf("Match(%s) wont %v, but got %v", tc.rule, tc.wontFlag, flag)
+		}
+	}
+}
+
+func TestCachedRules(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-rules")
+	defer os.RemoveAll(dir)
+	var file = filepath.Join(dir, "go.mod")
+	ioutil.WriteFile(file, []byte("module example"), 0644)
+	var repo = &Repository{ID: "cached", Path: dir}
+	var group = &Group{Name: "go", Rules: []string{"file:go.mod"}}
+	if !group.MatchRules(repo) {
+		t.Errorf("file:go.mod wont match, but did not match")
+	}
+	os.Remove(file)
+	if !group.MatchRules(repo) {
+		t.Errorf("the result of file:go.mod wont be cached in running a command")
+	}
+	group.Rules = []string{"id:other*"}
+	if group.MatchRules(repo) {
+		t.Errorf("the changed rules wont be parsed again, but the old rules are used")
+	}
+	group.Rules = []string{"unknown:pattern"}
+	if group.MatchRules(repo) {
+		t.Errorf("the invalid rules wont match no repositories")
+	}
+}
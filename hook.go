@@ -0,0 +1,79 @@
+package rrh
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+/*
+The values of RRH_HOOK_MODE.
+HookOffer prints the message for registering the unregistered repository,
+and HookAuto registers the unregistered repository automatically.
+*/
+const (
+	HookOffer = "offer"
+	HookAuto  = "auto"
+)
+
+func normalizeHookMode(value string) (string, error) {
+	var mode = strings.ToLower(value)
+	if mode == HookOffer || mode == HookAuto {
+		return mode, nil
+	}
+	return "", fmt.Errorf("%s: unknown value of %s (must be %s, or %s)", value, HookMode, HookOffer, HookAuto)
+}
+
+/*
+HookModeOf returns the value of RRH_HOOK_MODE.
+If the value is invalid, this function returns HookOffer.
+*/
+func HookModeOf(config *Config) string {
+	var mode, err = normalizeHookMode(config.GetValue(HookMode))
+	if err != nil {
+		return HookOffer
+	}
+	return mode
+}
+
+/*
+ParseHookIgnores parses the value of RRH_HOOK_IGNORES (e.g., `~/tmp,*.bak,/opt/*`).
+The home directory (`~` and `${HOME}`) in the patterns are expanded.
+*/
+func ParseHookIgnores(value string) []string {
+	var patterns = []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			patterns = append(patterns, expandHome(item))
+		}
+	}
+	return patterns
+}
+
+/*
+IsHookIgnored returns true if the given path matches the patterns in RRH_HOOK_IGNORES.
+The path matches the pattern if the pattern matches the path or its base name as the glob,
+or the path is in the directory of the pattern.
+*/
+func IsHookIgnored(config *Config, path string) bool {
+	var absPath, err = filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range ParseHookIgnores(config.GetValue(HookIgnores)) {
+		if matchHookIgnore(pattern, absPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchHookIgnore(pattern, path string) bool {
+	if ok, _ := filepath.Match(pattern, path); ok {
+		return true
+	}
+	if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+		return true
+	}
+	return filepath.IsAbs(pattern) && isContained(filepath.Clean(pattern), path)
+}
@@ -0,0 +1,55 @@
+package rrh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHookModeOf(t *testing.T) {
+	var testcases = []struct {
+		value    string
+		hasError bool
+		wont     string
+	}{
+		{"", false, HookOffer},
+		{"auto", false, HookAuto},
+		{"AUTO", false, HookAuto},
+		{"offer", false, HookOffer},
+		{"unknown", true, HookOffer},
+	}
+	for _, tc := range testcases {
+		var config = NewConfig()
+		var err = config.Update(HookMode, tc.value)
+		if tc.value != "" && (err != nil) != tc.hasError {
+			t.Errorf("%s: error flag did not match, wont: %v, got: %v", tc.value, tc.hasError, err)
+		}
+		if got := HookModeOf(config); got != tc.wont {
+			t.Errorf("%s: hook mode did not match, wont: %s, got: %s", tc.value, tc.wont, got)
+		}
+	}
+}
+
+func TestIsHookIgnored(t *testing.T) {
+	var home, _ = os.UserHomeDir()
+	var testcases = []struct {
+		ignores string
+		path    string
+		wont    bool
+	}{
+		{"", "/src/repo", false},
+		{"/tmp", "/tmp/work/repo", true},
+		{"/tmp", "/tmp2/repo", false},
+		{"/src/*-backup", "/src/repo-backup", true},
+		{"*.bak, vendor", "/src/vendor", true},
+		{"*.bak, vendor", "/src/repo", false},
+		{"~/tmp", filepath.Join(home, "tmp", "repo"), true},
+	}
+	for _, tc := range testcases {
+		var config = NewConfig()
+		config.Update(HookIgnores, tc.ignores)
+		if got := IsHookIgnored(config, tc.path); got != tc.wont {
+			t.Errorf("IsHookIgnored(%s, %s) did not match, wont: %v, got: %v", tc.ignores, tc.path, tc.wont, got)
+		}
+	}
+}
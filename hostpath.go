@@ -0,0 +1,143 @@
+package rrh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+/*
+HostPath represents the path of the repository on the specific host.
+The database shared among the machines holds the paths of each host in the repository,
+and the path of the current host is used as the path of the repository.
+*/
+type HostPath struct {
+	Host string `json:"host"`
+	Root string `json:"root,omitempty"`
+	Path string `json:"path"`
+}
+
+func (hp *HostPath) String() string {
+	return fmt.Sprintf("%s:%s", hp.Host, hp.Path)
+}
+
+/*
+CurrentHostname returns the name of the current host.
+The value of RRH_HOSTNAME takes precedence over the hostname of the system.
+*/
+func CurrentHostname(config *Config) string {
+	if name := config.GetValue(Hostname); name != "" {
+		return name
+	}
+	var name, err = os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+/*
+FindHostPath returns the path entry of the given host.
+*/
+func (r *Repository) FindHostPath(host string) *HostPath {
+	for _, hp := range r.HostPaths {
+		if hp.Host == host {
+			return hp
+		}
+	}
+	return nil
+}
+
+/*
+IsAvailableOn returns true if the path of the repository points the location on the given host.
+The repository under the workspace root not defined in the host, or whose path entry of the host is not resolved,
+is not available.
+*/
+func (r *Repository) IsAvailableOn(host string) bool {
+	if hp := r.FindHostPath(host); hp != nil && hp != r.current {
+		return false
+	}
+	return r.IsResolved()
+}
+
+/*
+HasSameRemote returns true if the repository has a remote whose url points the same repository of the given remotes.
+*/
+func (r *Repository) HasSameRemote(remotes []*Remote) bool {
+	for _, remote := range r.Remotes {
+		for _, other := range remotes {
+			if ParseRemoteURL(remote.URL).String() == ParseRemoteURL(other.URL).String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+PutHostPath puts the path of the repository on the given host.
+If the host is the current host, the path of the repository is also updated.
+*/
+func (db *Database) PutHostPath(repoID string, host string, path string) error {
+	var repo = db.FindRepository(repoID)
+	if repo == nil {
+		return fmt.Errorf("%s: repository not found", repoID)
+	}
+	if host == "" {
+		return fmt.Errorf("%s: empty host name", repoID)
+	}
+	var absPath, err = filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	var hp = repo.FindHostPath(host)
+	if hp == nil {
+		hp = &HostPath{Host: host}
+		repo.HostPaths = append(repo.HostPaths, hp)
+	}
+	hp.Root, hp.Path = "", absPath
+	if host == CurrentHostname(db.Config) {
+		selectHostPath(repo, hp)
+	}
+	repo.MarkUpdated()
+	return nil
+}
+
+func selectHostPath(repo *Repository, hp *HostPath) {
+	if repo.current == nil {
+		repo.defaultRoot, repo.defaultPath = repo.Root, repo.Path
+	}
+	repo.current = hp
+	repo.Path = hp.Path
+}
+
+func selectHostPaths(db *Database, host string, roots []*WorkspaceRoot) {
+	for _, repo := range db.Repositories {
+		var hp = repo.FindHostPath(host)
+		if hp == nil {
+			continue
+		}
+		var entry = &Repository{ID: repo.ID, Root: hp.Root, Path: hp.Path}
+		if err := ResolveRepositoryPath(entry, roots); err == nil {
+			hp.Path = entry.Path
+			selectHostPath(repo, hp)
+		}
+	}
+}
+
+/*
+portableHostPaths returns the path entries for storing.
+The entry of the current host is updated by the path of the repository,
+and the entries of the other hosts are stored as is, since the workspace roots of them are unknown in this host.
+*/
+func portableHostPaths(repo *Repository, roots []*WorkspaceRoot) []*HostPath {
+	var results = []*HostPath{}
+	for _, hp := range repo.HostPaths {
+		var entry = *hp
+		if hp == repo.current {
+			entry.Root, entry.Path = portablePath("", repo.Path, roots)
+		}
+		results = append(results, &entry)
+	}
+	return results
+}
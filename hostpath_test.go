@@ -0,0 +1,67 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHostPaths(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		config.Update(Hostname, "laptop")
+		db.CreateRepository("rrh", "/opt/src/rrh", "", []*Remote{})
+		if err := db.PutHostPath("rrh", "workstation", "/home/user/work/rrh"); err != nil {
+			t.Errorf("put host path failed: %s", err.Error())
+		}
+		if path := db.FindRepository("rrh").Path; path != "/opt/src/rrh" {
+			t.Errorf("path of the other host should not be selected, got: %s", path)
+		}
+		if err := db.PutHostPath("unknown", "workstation", "/tmp"); err == nil {
+			t.Errorf("put host path to unknown repository should be error")
+		}
+		db.StoreAndClose()
+
+		config.Update(Hostname, "workstation")
+		config.Update(WorkspaceRoots, "work=/home/user/work")
+		var db2, _ = Open(config)
+		if path := db2.FindRepository("rrh").Path; path != "/home/user/work/rrh" {
+			t.Errorf("path of the current host did not selected, wont: /home/user/work/rrh, got: %s", path)
+		}
+		db2.FindRepository("rrh").Path = "/home/user/work/tamada/rrh"
+		db2.StoreAndClose()
+
+		var bytes, _ = ioutil.ReadFile(config.GetValue(DatabasePath))
+		var content = string(bytes)
+		if !strings.Contains(content, `"repository_id":"rrh","repository_path":"/opt/src/rrh"`) {
+			t.Errorf("default path should be stored as is, got: %s", content)
+		}
+		if !strings.Contains(content, `{"host":"workstation","root":"work","path":"tamada/rrh"}`) {
+			t.Errorf("path of the current host should be updated, got: %s", content)
+		}
+
+		config.Update(Hostname, "laptop")
+		var db3, _ = Open(config)
+		if path := db3.FindRepository("rrh").Path; path != "/opt/src/rrh" {
+			t.Errorf("default path should be used on the host without the entry, got: %s", path)
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestHasSameRemote(t *testing.T) {
+	var repo = &Repository{ID: "rrh", Remotes: []*Remote{{Name: "origin", URL: "git@github.com:tamada/rrh.git"}}}
+	var testcases = []struct {
+		url  string
+		wont bool
+	}{
+		{"https://github.com/tamada/rrh", true},
+		{"git@github.com:tamada/rrh.git", true},
+		{"https://github.com/tamada/other.git", false},
+	}
+	for _, tc := range testcases {
+		if got := repo.HasSameRemote([]*Remote{{Name: "origin", URL: tc.url}}); got != tc.wont {
+			t.Errorf("HasSameRemote(%s) did not match, wont: %v, got: %v", tc.url, tc.wont, got)
+		}
+	}
+}
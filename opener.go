@@ -0,0 +1,80 @@
+package rrh
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+The names of the built-in openers.
+*/
+const (
+	EditorOpener   = "editor"
+	TerminalOpener = "terminal"
+)
+
+/*
+ParseOpeners parses the value of RRH_OPENERS (e.g., `idea=idea {{.Path}};tig=tig -C {{.Path}}`),
+and returns the map from the names to the command templates.
+The entries are separated by semicolons, since the templates may contain commas.
+*/
+func ParseOpeners(value string) (map[string]string, error) {
+	var openers = map[string]string{}
+	for _, item := range strings.Split(value, ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		var entries = strings.SplitN(item, "=", 2)
+		var name = strings.TrimSpace(entries[0])
+		if len(entries) != 2 || name == "" || strings.ContainsAny(name, " /") {
+			return nil, fmt.Errorf("%s: invalid opener, the opener must be NAME=COMMAND form", item)
+		}
+		if strings.TrimSpace(entries[1]) == "" {
+			return nil, fmt.Errorf("%s: empty opener command", name)
+		}
+		openers[name] = strings.TrimSpace(entries[1])
+	}
+	return openers, nil
+}
+
+/*
+FindOpener returns the command template of the opener of the given name.
+The editor opener is RRH_EDITOR_COMMAND, or $VISUAL and $EDITOR with the path if it is not set.
+The terminal opener is RRH_TERMINAL_COMMAND, and the other openers are defined in RRH_OPENERS.
+*/
+func (config *Config) FindOpener(name string) (string, error) {
+	switch name {
+	case EditorOpener:
+		return findEditorCommand(config)
+	case TerminalOpener:
+		return findOpenerCommand(config, TerminalCommand)
+	}
+	var openers, err = ParseOpeners(config.GetValue(Openers))
+	if err != nil {
+		return "", err
+	}
+	if command, ok := openers[name]; ok {
+		return command, nil
+	}
+	return "", fmt.Errorf("%s: opener not found, define it in %s", name, Openers)
+}
+
+func findEditorCommand(config *Config) (string, error) {
+	if command := config.GetValue(EditorCommand); command != "" {
+		return command, nil
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor + " {{.Path}}", nil
+		}
+	}
+	return "", fmt.Errorf("%s: opener command not set", EditorCommand)
+}
+
+func findOpenerCommand(config *Config, label string) (string, error) {
+	if command := config.GetValue(label); command != "" {
+		return command, nil
+	}
+	return "", fmt.Errorf("%s: opener command not set", label)
+}
@@ -0,0 +1,60 @@
+package rrh
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFindOpener(t *testing.T) {
+	os.Setenv(Openers, "idea=idea {{.Path}}; tig=tig -C {{.Path}}")
+	os.Setenv("EDITOR", "vim")
+	os.Unsetenv("VISUAL")
+	defer os.Unsetenv(Openers)
+	defer os.Unsetenv("EDITOR")
+	var testcases = []struct {
+		terminal string
+		name     string
+		hasError bool
+		wont     string
+	}{
+		{"", EditorOpener, false, "vim {{.Path}}"},
+		{"", TerminalOpener, true, ""},
+		{"kitty --directory {{.Path}}", TerminalOpener, false, "kitty --directory {{.Path}}"},
+		{"", "tig", false, "tig -C {{.Path}}"},
+		{"", "unknown", true, ""},
+	}
+	for _, tc := range testcases {
+		var config = NewConfig()
+		config.Update(TerminalCommand, tc.terminal)
+		var got, err = config.FindOpener(tc.name)
+		if (err != nil) != tc.hasError {
+			t.Errorf("FindOpener(%s) error flag did not match, wont: %v, got: %v", tc.name, tc.hasError, err)
+		}
+		if got != tc.wont {
+			t.Errorf("FindOpener(%s) did not match, wont: %s, got: %s", tc.name, tc.wont, got)
+		}
+	}
+}
+
+func TestParseOpeners(t *testing.T) {
+	var testcases = []struct {
+		value    string
+		hasError bool
+		wontSize int
+	}{
+		{"", false, 0},
+		{"idea=idea {{.Path}};code=code --add {{.Path}},{{.ID}}", false, 2},
+		{"idea", true, 0},
+		{"=idea {{.Path}}", true, 0},
+		{"idea= ", true, 0},
+	}
+	for _, tc := range testcases {
+		var openers, err = ParseOpeners(tc.value)
+		if (err != nil) != tc.hasError {
+			t.Errorf("ParseOpeners(%s) error flag did not match, wont: %v, got: %v", tc.value, tc.hasError, err)
+		}
+		if err == nil && len(openers) != tc.wontSize {
+			t.Errorf("ParseOpeners(%s) size did not match, wont: %d, got: %d", tc.value, tc.wontSize, len(openers))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package rrh
+
+import (
+	"os"
+	"path/filepath"
+)
+
+/*
+Relocation represents the new location of the repository whose path does not exist.
+NewPath is empty if no candidates or multiple candidates are found.
+*/
+type Relocation struct {
+	RepositoryID string
+	OldPath      string
+	NewPath      string
+	Candidates   []string
+}
+
+/*
+IsRelocatable returns true if the unique new location of the repository is found.
+*/
+func (r *Relocation) IsRelocatable() bool {
+	return r.NewPath != ""
+}
+
+/*
+IsMissing returns true if the path of the given repository does not exist on the given host.
+The repositories not available on the host (see Repository.IsAvailableOn) are not missing,
+since their paths could not be checked.
+*/
+func IsMissing(repo *Repository, host string) bool {
+	if !repo.IsAvailableOn(host) {
+		return false
+	}
+	var _, err = os.Stat(repo.Path)
+	return os.IsNotExist(err)
+}
+
+/*
+FindRelocations searches the git repositories in the given directories for the missing repositories of the given ids.
+The git repository is the candidate of the new location if its remotes point the same repository with the stored remotes.
+If no repository ids are given, all of the missing repositories are the targets.
+The repositories without remotes are not relocated, since they could not be identified.
+*/
+func (db *Database) FindRelocations(repoIDs []string, searchDirs []string) ([]*Relocation, error) {
+	var targets = db.findMissingRepositories(repoIDs)
+	if len(targets) == 0 {
+		return []*Relocation{}, nil
+	}
+	var paths, err = FindGitRepositories(searchDirs...)
+	if err != nil {
+		return nil, err
+	}
+	var candidates = db.findCandidates(paths)
+	var relocations = []*Relocation{}
+	for _, repo := range targets {
+		var relocation = &Relocation{RepositoryID: repo.ID, OldPath: repo.Path, Candidates: []string{}}
+		for _, path := range paths {
+			if remotes, ok := candidates[path]; ok && repo.HasSameRemote(remotes) {
+				relocation.Candidates = append(relocation.Candidates, path)
+			}
+		}
+		if len(relocation.Candidates) == 1 {
+			relocation.NewPath = relocation.Candidates[0]
+		}
+		relocations = append(relocations, relocation)
+	}
+	return relocations, nil
+}
+
+func (db *Database) findMissingRepositories(repoIDs []string) []*Repository {
+	var results = []*Repository{}
+	var host = CurrentHostname(db.Config)
+	for _, repo := range db.Repositories {
+		if (len(repoIDs) == 0 || FindIn(repo.ID, repoIDs)) && IsMissing(repo, host) {
+			results = append(results, repo)
+		}
+	}
+	return results
+}
+
+func (db *Database) findCandidates(paths []string) map[string][]*Remote {
+	var registered = map[string]bool{}
+	for _, repo := range db.Repositories {
+		registered[filepath.Clean(repo.Path)] = true
+	}
+	var candidates = map[string][]*Remote{}
+	for _, path := range paths {
+		if registered[filepath.Clean(path)] {
+			continue
+		}
+		if remotes, err := FindRemotes(path); err == nil && len(remotes) > 0 {
+			candidates[path] = remotes
+		}
+	}
+	return candidates
+}
+
+/*
+Relocate updates the path of the given repository on the current host (see PutHostPath).
+The paths of the other hosts and the groups of the repository are kept.
+*/
+func (db *Database) Relocate(repoID string, newPath string) error {
+	return db.PutHostPath(repoID, CurrentHostname(db.Config), newPath)
+}
@@ -0,0 +1,87 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+)
+
+func initGitRepository(t *testing.T, path string, url string) {
+	var repo, err = git.PlainInit(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{url}})
+}
+
+func TestFindRelocations(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-relocate")
+	defer os.RemoveAll(dir)
+	initGitRepository(t, filepath.Join(dir, "moved", "repo1"), "https://github.com/example/repo1.git")
+	initGitRepository(t, filepath.Join(dir, "clone1", "repo2"), "https://github.com/example/repo2.git")
+	initGitRepository(t, filepath.Join(dir, "clone2", "repo2"), "https://github.com/example/repo2.git")
+
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		db.CreateRepository("lost1", filepath.Join(dir, "repo1"), "", []*Remote{{Name: "origin", URL: "git@github.com:example/repo1.git"}})
+		db.CreateRepository("lost2", filepath.Join(dir, "repo2"), "", []*Remote{{Name: "origin", URL: "git@github.com:example/repo2.git"}})
+		db.CreateRepository("lost3", filepath.Join(dir, "repo3"), "", []*Remote{{Name: "origin", URL: "git@github.com:example/repo3.git"}})
+		db.Relate("group1", "lost1")
+
+		var relocations, err = db.FindRelocations([]string{"lost1", "lost2", "lost3"}, []string{dir})
+		if err != nil {
+			t.Errorf("find relocations failed: %s", err.Error())
+		}
+		var testcases = []struct {
+			repoID         string
+			wontPath       string
+			wontCandidates int
+		}{
+			{"lost1", filepath.Join(dir, "moved", "repo1"), 1},
+			{"lost2", "", 2},
+			{"lost3", "", 0},
+		}
+		if len(relocations) != len(testcases) {
+			t.Fatalf("relocations count did not match, wont: %d, got: %d", len(testcases), len(relocations))
+		}
+		for i, tc := range testcases {
+			var r = relocations[i]
+			if r.RepositoryID != tc.repoID || r.NewPath != tc.wontPath || len(r.Candidates) != tc.wontCandidates {
+				t.Errorf("relocation of %s did not match, got: %v", tc.repoID, r)
+			}
+		}
+		db.Relocate("lost1", relocations[0].NewPath)
+		if db.FindRepository("lost1").Path != relocations[0].NewPath || !db.HasRelation("group1", "lost1") {
+			t.Errorf("relocated repository should keep its groups")
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestRelocateOnHost(t *testing.T) {
+	os.Setenv(Hostname, "this-host")
+	defer os.Unsetenv(Hostname)
+	var dir, _ = ioutil.TempDir("", "rrh-relocate")
+	defer os.RemoveAll(dir)
+	initGitRepository(t, filepath.Join(dir, "moved", "repo1"), "https://github.com/example/repo1.git")
+
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		db.CreateRepository("lost1", filepath.Join(dir, "repo1"), "", []*Remote{{Name: "origin", URL: "git@github.com:example/repo1.git"}})
+		db.CreateRepository("other", "/not/exist/other", "", []*Remote{{Name: "origin", URL: "git@github.com:example/other.git"}})
+		db.FindRepository("other").HostPaths = []*HostPath{{Host: "this-host", Root: "undefined", Path: "example/other"}}
+
+		var relocations, _ = db.FindRelocations([]string{"lost1", "other"}, []string{dir})
+		if len(relocations) != 1 || relocations[0].RepositoryID != "lost1" {
+			t.Fatalf("only the missing repository on this host should be found, got: %v", relocations)
+		}
+		db.Relocate("lost1", relocations[0].NewPath)
+		var repo = db.FindRepository("lost1")
+		if hp := repo.FindHostPath("this-host"); hp == nil || hp.Path != relocations[0].NewPath || repo.Path != hp.Path {
+			t.Errorf("new location should be the path of this host, got: %s", repo.Path)
+		}
+	})
+	defer os.Remove(dbFile)
+}
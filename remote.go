@@ -0,0 +1,76 @@
+package rrh
+
+import (
+	"net/url"
+	"strings"
+)
+
+/*
+RemoteURL represents the parsed remote url of the git repository.
+For example, `git@github.com:tamada/rrh.git` is parsed into the host `github.com` and the path `tamada/rrh`.
+Scheme and Port are available only in the url with scheme (e.g., `https://example.com:8443/tamada/rrh`).
+*/
+type RemoteURL struct {
+	Scheme string
+	Host   string
+	Port   string
+	Path   string
+}
+
+/*
+String returns the `HOST/PATH` form of the remote url, such as `github.com/tamada/rrh`.
+*/
+func (r *RemoteURL) String() string {
+	if r.Host == "" {
+		return r.Path
+	}
+	return r.Host + "/" + r.Path
+}
+
+/*
+Owner returns the first element of the path of the remote url (e.g., the user or the organization of GitHub).
+*/
+func (r *RemoteURL) Owner() string {
+	return strings.SplitN(r.Path, "/", 2)[0]
+}
+
+/*
+ParseRemoteURL parses the given url of the remote repository.
+The available forms are the url with scheme (`https://github.com/tamada/rrh.git`, `ssh://git@github.com:22/tamada/rrh`),
+the scp-like form (`git@github.com:tamada/rrh.git`), and the local path (the host of the result is empty).
+*/
+func ParseRemoteURL(remoteURL string) *RemoteURL {
+	if strings.Contains(remoteURL, "://") {
+		if u, err := url.Parse(remoteURL); err == nil {
+			return &RemoteURL{Scheme: u.Scheme, Host: u.Hostname(), Port: u.Port(), Path: trimRemotePath(u.Path)}
+		}
+	}
+	if index := strings.Index(remoteURL, ":"); index > 0 && !strings.Contains(remoteURL[:index], "/") {
+		var host = remoteURL[:index]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		return &RemoteURL{Host: host, Path: trimRemotePath(remoteURL[index+1:])}
+	}
+	return &RemoteURL{Host: "", Path: strings.TrimSuffix(remoteURL, ".git")}
+}
+
+func trimRemotePath(path string) string {
+	return strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
+
+/*
+PrimaryRemote returns the remote named origin, or the first remote if the repository has no origin.
+This function returns nil if the repository has no remotes.
+*/
+func (r *Repository) PrimaryRemote() *Remote {
+	for _, remote := range r.Remotes {
+		if remote.Name == "origin" {
+			return remote
+		}
+	}
+	if len(r.Remotes) > 0 {
+		return r.Remotes[0]
+	}
+	return nil
+}
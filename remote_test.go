@@ -0,0 +1,46 @@
+package rrh
+
+import "testing"
+
+func TestParseRemoteURL(t *testing.T) {
+	var testcases = []struct {
+		giveURL   string
+		wontHost  string
+		wontPath  string
+		wontOwner string
+	}{
+		{"git@github.com:tamada/rrh.git", "github.com", "tamada/rrh", "tamada"},
+		{"https://github.com/tamada/rrh.git", "github.com", "tamada/rrh", "tamada"},
+		{"https://github.com/tamada/rrh", "github.com", "tamada/rrh", "tamada"},
+		{"ssh://git@gitlab.example.com:2222/acme/group/repo.git", "gitlab.example.com", "acme/group/repo", "acme"},
+		{"/home/user/repos/rrh.git", "", "/home/user/repos/rrh", ""},
+		{"../repos/rrh", "", "../repos/rrh", ".."},
+	}
+	for _, tc := range testcases {
+		var url = ParseRemoteURL(tc.giveURL)
+		if url.Host != tc.wontHost || url.Path != tc.wontPath {
+			t.Errorf("ParseRemoteURL(%s) did not match, wont: %s, %s, got: %s, %s", tc.giveURL, tc.wontHost, tc.wontPath, url.Host, url.Path)
+		}
+		if url.Owner() != tc.wontOwner {
+			t.Errorf("ParseRemoteURL(%s).Owner() did not match, wont: %s, got: %s", tc.giveURL, tc.wontOwner, url.Owner())
+		}
+	}
+}
+
+func TestPrimaryRemote(t *testing.T) {
+	var testcases = []struct {
+		remotes  []*Remote
+		wontName string
+	}{
+		{[]*Remote{{Name: "upstream", URL: "u"}, {Name: "origin", URL: "o"}}, "origin"},
+		{[]*Remote{{Name: "upstream", URL: "u"}, {Name: "fork", URL: "f"}}, "upstream"},
+		{[]*Remote{}, ""},
+	}
+	for _, tc := range testcases {
+		var repo = &Repository{ID: "repo", Remotes: tc.remotes}
+		var remote = repo.PrimaryRemote()
+		if (remote == nil && tc.wontName != "") || (remote != nil && remote.Name != tc.wontName) {
+			t.Errorf("PrimaryRemote(%v) did not match, wont: %s, got: %v", tc.remotes, tc.wontName, remote)
+		}
+	}
+}
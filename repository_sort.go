@@ -0,0 +1,271 @@
+package rrh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+RepositorySortKeys is the available keys for sorting the repositories.
+*/
+var RepositorySortKeys = []string{"id", "path", "group", "added-at", "updated-at", "last-accessed", "last-commit", "size"}
+
+/*
+repositoryComparator compares the repositories by the sort key.
+less compares the repositories having the values of the key,
+and present returns false if the repository has no value of the key (e.g., the never accessed repository for last-accessed).
+*/
+type repositoryComparator struct {
+	less    func(r1, r2 *Repository) bool
+	present func(repo *Repository) bool
+}
+
+type comparatorBuilder func(db *Database) *repositoryComparator
+
+var repositoryComparators = map[string]comparatorBuilder{
+	"id":            simpleComparator(func(r1, r2 *Repository) bool { return r1.ID < r2.ID }, nil),
+	"path":          simpleComparator(func(r1, r2 *Repository) bool { return r1.Path < r2.Path }, nil),
+	"group":         groupComparator,
+	"added-at":      timeComparator(func(repo *Repository) *RrhTime { return repo.AddedAt }),
+	"updated-at":    timeComparator(func(repo *Repository) *RrhTime { return repo.UpdatedAt }),
+	"last-accessed": timeComparator(func(repo *Repository) *RrhTime { return repo.LastAccessed }),
+	"last-commit":   lastCommitComparator,
+	"size":          sizeComparator,
+}
+
+/*
+simpleComparator returns the builder of the given comparator.
+The nil present function means that all of the repositories have the values.
+*/
+func simpleComparator(less func(r1, r2 *Repository) bool, present func(repo *Repository) bool) comparatorBuilder {
+	if present == nil {
+		present = func(repo *Repository) bool { return true }
+	}
+	return func(db *Database) *repositoryComparator {
+		return &repositoryComparator{less: less, present: present}
+	}
+}
+
+/*
+timeComparator compares the repositories by the given time in the newest first order.
+*/
+func timeComparator(timeOf func(repo *Repository) *RrhTime) comparatorBuilder {
+	return simpleComparator(func(r1, r2 *Repository) bool {
+		return isNewer(timeOf(r1), timeOf(r2))
+	}, func(repo *Repository) bool {
+		return timeOf(repo) != nil
+	})
+}
+
+/*
+groupComparator compares the repositories by the first group name of them in the alphabetical order.
+The repositories without groups have no values.
+*/
+func groupComparator(db *Database) *repositoryComparator {
+	var cache = map[string]string{}
+	var groupOf = func(repo *Repository) string {
+		if name, ok := cache[repo.ID]; ok {
+			return name
+		}
+		var name = ""
+		if db != nil {
+			var groups = db.FindRelationsOfRepository(repo.ID)
+			sort.Strings(groups)
+			if len(groups) > 0 {
+				name = groups[0]
+			}
+		}
+		cache[repo.ID] = name
+		return name
+	}
+	return &repositoryComparator{
+		less:    func(r1, r2 *Repository) bool { return groupOf(r1) < groupOf(r2) },
+		present: func(repo *Repository) bool { return groupOf(repo) != "" },
+	}
+}
+
+/*
+lastCommitComparator compares the repositories by the committed time of their HEAD in the newest first order.
+The time is read from the git repositories once for each repository,
+and the repositories whose HEAD could not be read have no values.
+*/
+func lastCommitComparator(db *Database) *repositoryComparator {
+	var cache = map[string]*time.Time{}
+	var timeOf = func(repo *Repository) *time.Time {
+		if t, ok := cache[repo.ID]; ok {
+			return t
+		}
+		var t, _ = LastCommitTime(repo.Path)
+		cache[repo.ID] = t
+		return t
+	}
+	return &repositoryComparator{
+		less:    func(r1, r2 *Repository) bool { return timeOf(r1).After(*timeOf(r2)) },
+		present: func(repo *Repository) bool { return timeOf(repo) != nil },
+	}
+}
+
+/*
+sizeComparator compares the repositories by the total size of the files in them in the largest first order.
+The repositories whose paths do not exist have no values.
+*/
+func sizeComparator(db *Database) *repositoryComparator {
+	var cache = map[string]int64{}
+	var sizeOf = func(repo *Repository) int64 {
+		if size, ok := cache[repo.ID]; ok {
+			return size
+		}
+		var size = directorySize(repo.Path)
+		cache[repo.ID] = size
+		return size
+	}
+	return &repositoryComparator{
+		less:    func(r1, r2 *Repository) bool { return sizeOf(r1) > sizeOf(r2) },
+		present: func(repo *Repository) bool { return sizeOf(repo) >= 0 },
+	}
+}
+
+/*
+directorySize returns the total size of the files in the given directory, or -1 if the directory does not exist.
+*/
+func directorySize(path string) int64 {
+	if _, err := os.Stat(path); err != nil {
+		return -1
+	}
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size = size + info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+/*
+isNewer returns true if t1 is newer than t2.
+The nil time is treated as the oldest.
+*/
+func isNewer(t1, t2 *RrhTime) bool {
+	if t1 == nil || t2 == nil {
+		return t1 != nil
+	}
+	return t1.Time().After(t2.Time())
+}
+
+/*
+RepositorySorter sorts the repositories, and the groups by the sort key.
+The keys of the time (added-at, updated-at, last-accessed, and last-commit) sort the repositories in the newest first order,
+the size key sorts them in the largest first order,
+and the repositories without the values are placed at the last.
+The reverse flag reverses the order of the repositories having the values, and the repositories without them are still placed at the last.
+*/
+type RepositorySorter struct {
+	Key        string
+	Reverse    bool
+	comparator *repositoryComparator
+}
+
+/*
+NewRepositorySorter creates the sorter of the given key (see RepositorySortKeys).
+The database is referred for the group key, and may be nil.
+*/
+func NewRepositorySorter(db *Database, key string, reverse bool) (*RepositorySorter, error) {
+	var key2 = strings.ToLower(key)
+	var builder, ok = repositoryComparators[key2]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown sort key, availables: %s", key, strings.Join(RepositorySortKeys, ", "))
+	}
+	return &RepositorySorter{Key: key2, Reverse: reverse, comparator: builder(db)}, nil
+}
+
+/*
+IsGroupKey returns true if the sort key is the group.
+*/
+func (s *RepositorySorter) IsGroupKey() bool {
+	return s.Key == "group"
+}
+
+/*
+Less returns true if r1 should be placed before r2.
+*/
+func (s *RepositorySorter) Less(r1, r2 *Repository) bool {
+	var p1, p2 = s.comparator.present(r1), s.comparator.present(r2)
+	if !p1 || !p2 {
+		return p1 && !p2
+	}
+	if s.Reverse {
+		return s.comparator.less(r2, r1)
+	}
+	return s.comparator.less(r1, r2)
+}
+
+/*
+LessGroupNames returns true if the group name g1 should be placed before g2.
+*/
+func (s *RepositorySorter) LessGroupNames(g1, g2 string) bool {
+	if s.Reverse {
+		return g2 < g1
+	}
+	return g1 < g2
+}
+
+/*
+Sort sorts the given repositories, the order of the equal repositories is kept.
+*/
+func (s *RepositorySorter) Sort(repos []*Repository) {
+	sort.SliceStable(repos, func(i, j int) bool {
+		return s.Less(repos[i], repos[j])
+	})
+}
+
+/*
+SortGroups sorts the given groups.
+The group key sorts the groups by their names,
+and the other keys sort the groups by their first repository in the order of the key.
+The groups without repositories are placed at the last.
+*/
+func (s *RepositorySorter) SortGroups(db *Database, groups []*Group) {
+	if s.IsGroupKey() {
+		sort.SliceStable(groups, func(i, j int) bool {
+			return s.LessGroupNames(groups[i].Name, groups[j].Name)
+		})
+		return
+	}
+	var tops = map[string]*Repository{}
+	for _, group := range groups {
+		var repos = []*Repository{}
+		for _, repoID := range db.FindRelationsOfGroup(group.Name) {
+			if repo := db.FindRepository(repoID); repo != nil {
+				repos = append(repos, repo)
+			}
+		}
+		s.Sort(repos)
+		if len(repos) > 0 {
+			tops[group.Name] = repos[0]
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		var r1, r2 = tops[groups[i].Name], tops[groups[j].Name]
+		if r1 == nil || r2 == nil {
+			return r1 != nil
+		}
+		return s.Less(r1, r2)
+	})
+}
+
+/*
+SortRepositories sorts the given repositories by the given key (see RepositorySorter).
+*/
+func SortRepositories(repos []*Repository, key string) error {
+	var sorter, err = NewRepositorySorter(nil, key, false)
+	if err != nil {
+		return err
+	}
+	sorter.Sort(repos)
+	return nil
+}
@@ -0,0 +1,113 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func repositoryIDsOf(repos []*Repository) string {
+	var ids = []string{}
+	for _, repo := range repos {
+		ids = append(ids, repo.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestRepositorySorter(t *testing.T) {
+	var small, _ = ioutil.TempDir("", "rrh-sort")
+	var large, _ = ioutil.TempDir("", "rrh-sort")
+	defer os.RemoveAll(small)
+	defer os.RemoveAll(large)
+	ioutil.WriteFile(filepath.Join(small, "file"), []byte("a"), 0644)
+	ioutil.WriteFile(filepath.Join(large, "file"), []byte("abcdefghij"), 0644)
+
+	var testcases = []struct {
+		key       string
+		reverse   bool
+		errorFlag bool
+		wontIDs   string
+	}{
+		{"id", true, false, "c,b,a"},
+		{"size", false, false, "b,a,c"},
+		{"size", true, false, "a,b,c"},
+		{"last-commit", false, false, "a,b,c"},
+		{"unknown", false, true, ""},
+	}
+	for _, tc := range testcases {
+		var repos = []*Repository{{ID: "a", Path: small}, {ID: "b", Path: large}, {ID: "c", Path: "not-exist"}}
+		var sorter, err = NewRepositorySorter(nil, tc.key, tc.reverse)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("NewRepositorySorter(%s) wont error %v, but got %v", tc.key, tc.errorFlag, err)
+		}
+		if err != nil {
+			continue
+		}
+		sorter.Sort(repos)
+		if got := repositoryIDsOf(repos); got != tc.wontIDs {
+			t.Errorf("sort by %s (reverse: %v) did not match, wont: %s, got: %s", tc.key, tc.reverse, tc.wontIDs, got)
+		}
+	}
+}
+
+func TestRepositorySorterKeepsMissingValuesLast(t *testing.T) {
+	var older, newer = Unix(1000000000, 0), Unix(1000086400, 0)
+	var testcases = []struct {
+		reverse bool
+		wontIDs string
+	}{
+		{false, "b,a,c"},
+		{true, "a,b,c"},
+	}
+	for _, tc := range testcases {
+		var repos = []*Repository{{ID: "c"}, {ID: "a", AddedAt: &older}, {ID: "b", AddedAt: &newer}}
+		var sorter, _ = NewRepositorySorter(nil, "added-at", tc.reverse)
+		sorter.Sort(repos)
+		if got := repositoryIDsOf(repos); got != tc.wontIDs {
+			t.Errorf("sort by added-at (reverse: %v) did not match, wont: %s, got: %s", tc.reverse, tc.wontIDs, got)
+		}
+	}
+}
+
+func TestSortGroups(t *testing.T) {
+	var testcases = []struct {
+		key     string
+		reverse bool
+		wont    string
+	}{
+		{"group", true, "group3,group2,group1"},
+		{"group", false, "group1,group2,group3"},
+		{"id", true, "group3,group1,group2"},
+		{"path", false, "group1,group3,group2"},
+	}
+	for _, tc := range testcases {
+		var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+			var sorter, _ = NewRepositorySorter(db, tc.key, tc.reverse)
+			var groups = append([]*Group{}, db.Groups...)
+			sorter.SortGroups(db, groups)
+			var names = []string{}
+			for _, group := range groups {
+				names = append(names, group.Name)
+			}
+			if got := strings.Join(names, ","); got != tc.wont {
+				t.Errorf("sort groups by %s (reverse: %v) did not match, wont: %s, got: %s", tc.key, tc.reverse, tc.wont, got)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestSortRepositoriesByGroup(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		db.CreateRepository("repo3", "path3", "", []*Remote{})
+		var repos = []*Repository{db.FindRepository("repo3"), db.FindRepository("repo2"), db.FindRepository("repo1")}
+		var sorter, _ = NewRepositorySorter(db, "group", false)
+		sorter.Sort(repos)
+		if got := repositoryIDsOf(repos); got != "repo1,repo2,repo3" {
+			t.Errorf("sort by group did not match, wont: repo1,repo2,repo3, got: %s", got)
+		}
+	})
+	defer os.Remove(dbFile)
+}
@@ -0,0 +1,64 @@
+package rrh
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+/*
+MarkUpdated records the current time as the updated time of the repository.
+*/
+func (repo *Repository) MarkUpdated() {
+	var now = Now()
+	repo.UpdatedAt = &now
+}
+
+/*
+Touch records the current time as the last accessed time of the repository of the given id,
+and increments the access count of the repository.
+*/
+func (db *Database) Touch(repoID string) error {
+	var repo = db.FindRepository(repoID)
+	if repo == nil {
+		return fmt.Errorf("%s: repository not found", repoID)
+	}
+	var now = Now()
+	repo.LastAccessed = &now
+	repo.AccessCount++
+	return nil
+}
+
+/*
+FindRepositoryByPath returns the repository which contains the given path.
+If two or more repositories contain the path (e.g., nested repositories), the deepest one is returned.
+*/
+func (db *Database) FindRepositoryByPath(path string) *Repository {
+	var absPath, err = filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	var result *Repository
+	for _, repo := range db.Repositories {
+		if isContained(filepath.Clean(repo.Path), absPath) && (result == nil || len(repo.Path) > len(result.Path)) {
+			result = repo
+		}
+	}
+	return result
+}
+
+func isContained(parent string, path string) bool {
+	var rel, err = filepath.Rel(parent, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+/*
+StrftimeOf returns the string of the given time by RRH_TIME_FORMAT.
+If the given time is nil, this function returns the empty string.
+*/
+func StrftimeOf(rt *RrhTime, config *Config) string {
+	if rt == nil {
+		return ""
+	}
+	return Strftime(rt.Time(), config)
+}
@@ -0,0 +1,99 @@
+package rrh
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepositoryTimes(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		var repo, _ = db.CreateRepository("repo3", "path3", "", []*Remote{})
+		if repo.AddedAt == nil || repo.UpdatedAt == nil || repo.LastAccessed != nil {
+			t.Errorf("created repository should have added and updated times, and no accessed time")
+		}
+		var repo1 = db.FindRepository("repo1")
+		if repo1.UpdatedAt != nil || repo1.LastAccessed != nil {
+			t.Errorf("repository in the database should have no times")
+		}
+		db.TagRepository("repo1", []*Tag{{Key: "lang", Value: "go"}})
+		if repo1.UpdatedAt == nil {
+			t.Errorf("tagging should update the updated time")
+		}
+		if err := db.Touch("repo1"); err != nil || repo1.LastAccessed == nil || repo1.AccessCount != 1 {
+			t.Errorf("touch should update the last accessed time and the access count, got error: %v", err)
+		}
+		if err := db.Touch("unknown"); err == nil {
+			t.Errorf("touching unknown repository should be error")
+		}
+		if StrftimeOf(nil, config) != "" {
+			t.Errorf("the string of nil time should be empty")
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestFindRepositoryByPath(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		var dir, _ = filepath.Abs("testdata")
+		db.CreateRepository("outer", dir, "", []*Remote{})
+		db.CreateRepository("inner", filepath.Join(dir, "inner"), "", []*Remote{})
+		var testcases = []struct {
+			givePath string
+			wontID   string
+		}{
+			{dir, "outer"},
+			{filepath.Join(dir, "sub", "dir"), "outer"},
+			{filepath.Join(dir, "inner"), "inner"},
+			{filepath.Join(dir, "inner", "sub"), "inner"},
+			{filepath.Join(dir, "inner2"), "outer"},
+			{filepath.Dir(dir), ""},
+		}
+		for _, tc := range testcases {
+			var gotID = ""
+			if repo := db.FindRepositoryByPath(tc.givePath); repo != nil {
+				gotID = repo.ID
+			}
+			if gotID != tc.wontID {
+				t.Errorf("FindRepositoryByPath(%s) did not match, wont: %s, got: %s", tc.givePath, tc.wontID, gotID)
+			}
+		}
+	})
+	defer os.Remove(dbFile)
+}
+
+func TestSortRepositories(t *testing.T) {
+	var t1, t2 = Unix(100, 0), Unix(200, 0)
+	var repos = []*Repository{
+		{ID: "b", Path: "/c", LastAccessed: &t1},
+		{ID: "a", Path: "/b"},
+		{ID: "c", Path: "/a", LastAccessed: &t2},
+	}
+	var testcases = []struct {
+		key       string
+		errorFlag bool
+		wontIDs   string
+	}{
+		{"id", false, "a,b,c"},
+		{"path", false, "c,a,b"},
+		{"last-accessed", false, "c,b,a"},
+		{"unknown", true, ""},
+	}
+	for _, tc := range testcases {
+		var err = SortRepositories(repos, tc.key)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("SortRepositories(%s) wont error %v, but got %v", tc.key, tc.errorFlag, err)
+		}
+		if err != nil {
+			continue
+		}
+		var ids = []string{}
+		for _, repo := range repos {
+			ids = append(ids, repo.ID)
+		}
+		if strings.Join(ids, ",") != tc.wontIDs {
+			t.Errorf("SortRepositories(%s) did not match, wont: %s, got: %s", tc.key, tc.wontIDs, strings.Join(ids, ","))
+		}
+	}
+}
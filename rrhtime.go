@@ -45,3 +45,10 @@ MarshalJSON is called on marshaling JSON.
 func (rt RrhTime) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf(`"%s"`, rt.format())), nil
 }
+
+/*
+Time returns the time.Time of the receiver.
+*/
+func (rt RrhTime) Time() time.Time {
+	return rt.time
+}
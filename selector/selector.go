@@ -0,0 +1,226 @@
+/*
+Package selector provides the set-algebra selectors of the groups.
+
+The selector consists of the terms separated by `,` (union), and each term consists of the factors separated by `&` (intersection).
+The factor is a group name, or a glob pattern of group names (e.g., `team-*`), and `!` prefix negates the factor.
+The repositories of a group include the repositories of its descendant groups.
+The terms having only negated factors exclude the repositories from the result,
+therefore, `work,!legacy` means the repositories in work but not in legacy, and `go&backend` means the repositories in both go and backend.
+If the selector has no positive terms, the negated terms are applied to all repositories.
+*/
+package selector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tamada/rrh"
+)
+
+type factor struct {
+	name    string
+	negated bool
+}
+
+type term struct {
+	factors []factor
+}
+
+/*
+Selector represents the parsed selector of the groups.
+*/
+type Selector struct {
+	expr  string
+	terms []term
+}
+
+/*
+Result shows the resolved result of the selector.
+Groups is the group names appearing in the positive factors (the glob patterns are expanded).
+Groups is empty if the selector has no positive factors, which means all groups.
+Repositories is the ids of the selected repositories in the order of the database.
+*/
+type Result struct {
+	Groups       []string
+	Repositories []string
+}
+
+/*
+Parse parses the given selector string.
+*/
+func Parse(expr string) (*Selector, error) {
+	var selector = &Selector{expr: expr}
+	for _, item := range strings.Split(expr, ",") {
+		var t, err = parseTerm(expr, item)
+		if err != nil {
+			return nil, err
+		}
+		selector.terms = append(selector.terms, t)
+	}
+	return selector, nil
+}
+
+func parseTerm(expr, item string) (term, error) {
+	var t = term{}
+	for _, str := range strings.Split(item, "&") {
+		str = strings.TrimSpace(str)
+		var f = factor{name: strings.TrimSpace(strings.TrimPrefix(str, "!")), negated: strings.HasPrefix(str, "!")}
+		if f.name == "" {
+			return t, fmt.Errorf("%s: empty group name in the selector", expr)
+		}
+		if _, err := path.Match(f.name, ""); err != nil {
+			return t, fmt.Errorf("%s: invalid glob pattern (%s)", f.name, err.Error())
+		}
+		t.factors = append(t.factors, f)
+	}
+	return t, nil
+}
+
+func (s *Selector) String() string {
+	return s.expr
+}
+
+/*
+Resolve resolves the given arguments as a selector.
+The multiple arguments are joined with `,`, that is, the union of them.
+*/
+func Resolve(db *rrh.Database, args []string) (*Result, error) {
+	var selector, err = Parse(strings.Join(args, ","))
+	if err != nil {
+		return nil, err
+	}
+	return selector.Resolve(db)
+}
+
+/*
+Resolve finds the groups and the repositories selected by the selector from the given database.
+*/
+func (s *Selector) Resolve(db *rrh.Database) (*Result, error) {
+	var members = map[string][]string{}
+	var result = &Result{Groups: []string{}, Repositories: []string{}}
+	for _, t := range s.terms {
+		for _, f := range t.factors {
+			var groups, err = findGroups(db, f.name)
+			if err != nil {
+				return nil, err
+			}
+			members[f.name] = findMembers(db, groups)
+			if !f.negated {
+				result.Groups = appendIfAbsent(result.Groups, groups...)
+			}
+		}
+	}
+	for _, repo := range db.Repositories {
+		if s.match(repo.ID, members) {
+			result.Repositories = append(result.Repositories, repo.ID)
+		}
+	}
+	return result, nil
+}
+
+func (s *Selector) match(repoID string, members map[string][]string) bool {
+	var hasPositive, matchPositive = false, false
+	for _, t := range s.terms {
+		if !t.hasPositive() {
+			if !t.match(repoID, members) {
+				return false
+			}
+			continue
+		}
+		hasPositive = true
+		matchPositive = matchPositive || t.match(repoID, members)
+	}
+	return !hasPositive || matchPositive
+}
+
+func (t term) hasPositive() bool {
+	for _, f := range t.factors {
+		if !f.negated {
+			return true
+		}
+	}
+	return false
+}
+
+func (t term) match(repoID string, members map[string][]string) bool {
+	for _, f := range t.factors {
+		if rrh.FindIn(repoID, members[f.name]) == f.negated {
+			return false
+		}
+	}
+	return true
+}
+
+func isGlob(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+func findGroups(db *rrh.Database, name string) ([]string, error) {
+	if !isGlob(name) {
+		if !db.HasGroup(name) {
+			return nil, fmt.Errorf("%s: group not found", name)
+		}
+		return []string{name}, nil
+	}
+	var results = []string{}
+	for _, group := range db.Groups {
+		if matched, _ := path.Match(name, group.Name); matched {
+			results = append(results, group.Name)
+		}
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%s: no groups matched", name)
+	}
+	return results, nil
+}
+
+func findMembers(db *rrh.Database, groups []string) []string {
+	var results = []string{}
+	for _, group := range groups {
+		results = appendIfAbsent(results, db.FindRelationsOfGroupRecursively(group)...)
+	}
+	return results
+}
+
+func appendIfAbsent(list []string, items ...string) []string {
+	for _, item := range items {
+		if !rrh.FindIn(item, list) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+/*
+Contains returns true if the result contains the given repository.
+*/
+func (r *Result) Contains(repoID string) bool {
+	return rrh.FindIn(repoID, r.Repositories)
+}
+
+/*
+IsAllGroups returns true if the selector has no positive factors, that is, all groups are the targets.
+*/
+func (r *Result) IsAllGroups() bool {
+	return len(r.Groups) == 0
+}
+
+/*
+Relations returns the relations of the selected groups (and their descendant groups) and the selected repositories.
+*/
+func (r *Result) Relations(db *rrh.Database) []rrh.Relation {
+	var groups = r.Groups
+	if r.IsAllGroups() {
+		for _, group := range db.Groups {
+			groups = append(groups, group.Name)
+		}
+	}
+	var results = []rrh.Relation{}
+	for _, relation := range rrh.FindTargets(db, groups) {
+		if r.Contains(relation.RepositoryID) {
+			results = append(results, relation)
+		}
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+package selector
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tamada/rrh"
+)
+
+func TestParseError(t *testing.T) {
+	var testcases = []string{"", "group1,", "group1&!", "group[", "!"}
+	for _, tc := range testcases {
+		if _, err := Parse(tc); err == nil {
+			t.Errorf("Parse(%s) should be error", tc)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var testcases = []struct {
+		args       []string
+		wontError  bool
+		wontGroups []string
+		wontRepos  []string
+	}{
+		{[]string{"group1"}, false, []string{"group1"}, []string{"repo1", "repo2"}},
+		{[]string{"group1", "group3"}, false, []string{"group1", "group3"}, []string{"repo1", "repo2"}},
+		{[]string{"group1,!group3"}, false, []string{"group1"}, []string{"repo1"}},
+		{[]string{"group1", "!group3"}, false, []string{"group1"}, []string{"repo1"}},
+		{[]string{"group1&group3"}, false, []string{"group1", "group3"}, []string{"repo2"}},
+		{[]string{"group1&!group3"}, false, []string{"group1"}, []string{"repo1"}},
+		{[]string{"!group3"}, false, []string{}, []string{"repo1", "repo3"}},
+		{[]string{"group*,!group1"}, false, []string{"group1", "group2", "group3"}, []string{"repo3"}},
+		{[]string{"team-*"}, false, []string{"team-a", "team-b"}, []string{"repo3"}},
+		{[]string{"parent"}, false, []string{"parent"}, []string{"repo1", "repo2"}},
+		{[]string{"unknown"}, true, []string{}, []string{}},
+		{[]string{"unknown-*"}, true, []string{}, []string{}},
+	}
+	for _, tc := range testcases {
+		var dbFile = rrh.Rollback("../testdata/test_db.json", "../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+			db.CreateRepository("repo3", "path3", "", []*rrh.Remote{})
+			db.CreateGroup("team-a", "", false)
+			db.CreateGroup("team-b", "", false)
+			db.CreateGroup("parent", "", false)
+			db.SetParentGroup("group1", "parent")
+			db.Relate("group1", "repo2")
+			db.Relate("group2", "repo3")
+			db.Relate("team-b", "repo3")
+			var result, err = Resolve(db, tc.args)
+			if (err != nil) != tc.wontError {
+				t.Errorf("Resolve(%v) wont error %v, but got %v", tc.args, tc.wontError, err)
+			}
+			if err != nil {
+				return
+			}
+			if strings.Join(result.Groups, ",") != strings.Join(tc.wontGroups, ",") {
+				t.Errorf("Resolve(%v) groups did not match, wont: %v, got: %v", tc.args, tc.wontGroups, result.Groups)
+			}
+			if strings.Join(result.Repositories, ",") != strings.Join(tc.wontRepos, ",") {
+				t.Errorf("Resolve(%v) repositories did not match, wont: %v, got: %v", tc.args, tc.wontRepos, result.Repositories)
+			}
+		})
+		defer os.Remove(dbFile)
+	}
+}
+
+func TestRelations(t *testing.T) {
+	var dbFile = rrh.Rollback("../testdata/test_db.json", "../testdata/config.json", func(config *rrh.Config, db *rrh.Database) {
+		db.Relate("group1", "repo2")
+		var result, _ = Resolve(db, []string{"group1,!group3"})
+		var relations = result.Relations(db)
+		if len(relations) != 1 || relations[0].String() != "group1/repo1" {
+			t.Errorf("relations did not match, wont: [group1/repo1], got: %v", relations)
+		}
+		result, _ = Resolve(db, []string{"!group1"})
+		relations = result.Relations(db)
+		if len(relations) != 0 {
+			t.Errorf("relations did not match, wont: [], got: %v", relations)
+		}
+	})
+	defer os.Remove(dbFile)
+}
@@ -0,0 +1,200 @@
+package rrh
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+/*
+Tag represents the key/value attribute of the repository, such as `lang=go`.
+The tag without value (e.g., `archived`) has the empty value.
+*/
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+func (tag *Tag) String() string {
+	if tag.Value == "" {
+		return tag.Key
+	}
+	return fmt.Sprintf("%s=%s", tag.Key, tag.Value)
+}
+
+/*
+ParseTag parses the given string in the form of `KEY` or `KEY=VALUE`, and returns the instance of Tag.
+*/
+func ParseTag(str string) (*Tag, error) {
+	var items = strings.SplitN(str, "=", 2)
+	var key = strings.TrimSpace(items[0])
+	if err := validateTagKey(key, str); err != nil {
+		return nil, err
+	}
+	if len(items) == 1 {
+		return &Tag{Key: key}, nil
+	}
+	return &Tag{Key: key, Value: strings.TrimSpace(items[1])}, nil
+}
+
+func validateTagKey(key, original string) error {
+	if key == "" {
+		return fmt.Errorf("%s: tag key must not be empty", original)
+	}
+	if strings.ContainsAny(key, " \t,!") {
+		return fmt.Errorf("%s: tag key must not contain spaces, commas, nor exclamation marks", original)
+	}
+	return nil
+}
+
+/*
+TagStrings returns the string representations of the tags of the repository.
+*/
+func (repo *Repository) TagStrings() []string {
+	var results = []string{}
+	for _, tag := range repo.Tags {
+		results = append(results, tag.String())
+	}
+	return results
+}
+
+/*
+FindTag returns the tag of the given key in the repository.
+If the repository does not have the tag, this method returns nil.
+*/
+func (repo *Repository) FindTag(key string) *Tag {
+	for _, tag := range repo.Tags {
+		if tag.Key == key {
+			return tag
+		}
+	}
+	return nil
+}
+
+/*
+HasTag returns true if the repository has the tag of the given key.
+*/
+func (repo *Repository) HasTag(key string) bool {
+	return repo.FindTag(key) != nil
+}
+
+/*
+PutTag adds the given tag to the repository.
+If the repository already has the tag of the same key, the value of it is replaced.
+*/
+func (repo *Repository) PutTag(tag *Tag) {
+	if found := repo.FindTag(tag.Key); found != nil {
+		found.Value = tag.Value
+		return
+	}
+	repo.Tags = append(repo.Tags, tag)
+}
+
+/*
+RemoveTag removes the tag of the given key from the repository.
+The return value shows the repository had the tag or not.
+*/
+func (repo *Repository) RemoveTag(key string) bool {
+	var tags = []*Tag{}
+	for _, tag := range repo.Tags {
+		if tag.Key != key {
+			tags = append(tags, tag)
+		}
+	}
+	var removed = len(tags) != len(repo.Tags)
+	repo.Tags = tags
+	return removed
+}
+
+/*
+ValidateTagSelectors validates the given tag selectors.
+The available forms of selectors are `KEY`, `KEY=VALUE`, `!KEY`, and `!KEY=VALUE`.
+The VALUE allows the glob pattern, such as `team=pay*`.
+*/
+func ValidateTagSelectors(selectors []string) error {
+	for _, selector := range selectors {
+		var tag, err = ParseTag(strings.TrimPrefix(selector, "!"))
+		if err != nil {
+			return err
+		}
+		if _, err := path.Match(tag.Value, ""); err != nil {
+			return fmt.Errorf("%s: invalid tag selector (%s)", selector, err.Error())
+		}
+	}
+	return nil
+}
+
+/*
+MatchTags returns true if the repository satisfies all of the given tag selectors.
+If no selectors are given, this method returns true.
+*/
+func (repo *Repository) MatchTags(selectors []string) bool {
+	for _, selector := range selectors {
+		if !repo.matchTag(selector) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+matchTag returns true if the repository satisfies the given selector.
+The negated selector (`!KEY` or `!KEY=VALUE`) is satisfied when the positive form is not satisfied.
+*/
+func (repo *Repository) matchTag(selector string) bool {
+	if strings.HasPrefix(selector, "!") {
+		var body = strings.TrimPrefix(selector, "!")
+		if _, err := ParseTag(body); err != nil {
+			return false
+		}
+		return !repo.matchTag(body)
+	}
+	var wanted, err = ParseTag(selector)
+	if err != nil {
+		return false
+	}
+	var tag = repo.FindTag(wanted.Key)
+	if tag == nil {
+		return false
+	}
+	if !strings.Contains(selector, "=") {
+		return true
+	}
+	var matched, _ = path.Match(wanted.Value, tag.Value)
+	return matched
+}
+
+/*
+TagRepository puts the given tags to the repository of repoID.
+*/
+func (db *Database) TagRepository(repoID string, tags []*Tag) error {
+	var repo = db.FindRepository(repoID)
+	if repo == nil {
+		return fmt.Errorf("%s: repository not found", repoID)
+	}
+	for _, tag := range tags {
+		repo.PutTag(tag)
+	}
+	repo.MarkUpdated()
+	return nil
+}
+
+/*
+UntagRepository removes the tags of the given keys from the repository of repoID.
+*/
+func (db *Database) UntagRepository(repoID string, keys []string) error {
+	var repo = db.FindRepository(repoID)
+	if repo == nil {
+		return fmt.Errorf("%s: repository not found", repoID)
+	}
+	for _, key := range keys {
+		if !repo.HasTag(key) {
+			return fmt.Errorf("%s: tag %s not found", repoID, key)
+		}
+	}
+	for _, key := range keys {
+		repo.RemoveTag(key)
+	}
+	repo.MarkUpdated()
+	return nil
+}
@@ -0,0 +1,113 @@
+package rrh
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	var testcases = []struct {
+		giveString string
+		errorFlag  bool
+		key        string
+		value      string
+		stringer   string
+	}{
+		{"lang=go", false, "lang", "go", "lang=go"},
+		{"archived", false, "archived", "", "archived"},
+		{" team = payments ", false, "team", "payments", "team=payments"},
+		{"url=https://example.com/?a=b", false, "url", "https://example.com/?a=b", "url=https://example.com/?a=b"},
+		{"=go", true, "", "", ""},
+		{"la ng=go", true, "", "", ""},
+		{"!archived", true, "", "", ""},
+	}
+	for _, tc := range testcases {
+		var tag, err = ParseTag(tc.giveString)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("ParseTag(%s) wont error %v, but got %v", tc.giveString, tc.errorFlag, err)
+		}
+		if err != nil {
+			continue
+		}
+		if tag.Key != tc.key || tag.Value != tc.value {
+			t.Errorf("ParseTag(%s) did not match, wont: %s/%s, got: %s/%s", tc.giveString, tc.key, tc.value, tag.Key, tag.Value)
+		}
+		if tag.String() != tc.stringer {
+			t.Errorf("ParseTag(%s).String() did not match, wont: %s, got: %s", tc.giveString, tc.stringer, tag.String())
+		}
+	}
+}
+
+func TestMatchTags(t *testing.T) {
+	var repo = &Repository{ID: "repo1", Tags: []*Tag{{"lang", "go"}, {"team", "payments"}, {"archived", ""}}}
+	var testcases = []struct {
+		selectors []string
+		wontFlag  bool
+	}{
+		{[]string{}, true},
+		{[]string{"lang=go"}, true},
+		{[]string{"lang=rust"}, false},
+		{[]string{"lang"}, true},
+		{[]string{"team=pay*"}, true},
+		{[]string{"lang=go", "team=payments"}, true},
+		{[]string{"lang=go", "team=backend"}, false},
+		{[]string{"!archived"}, false},
+		{[]string{"!deprecated"}, true},
+		{[]string{"archived="}, true},
+		{[]string{"!lang=go"}, false},
+		{[]string{"!lang=rust"}, true},
+		{[]string{"!team=pay*"}, false},
+		{[]string{"!owner=someone"}, true},
+		{[]string{"lang", "!lang=rust"}, true},
+	}
+	for _, tc := range testcases {
+		if flag := repo.MatchTags(tc.selectors); flag != tc.wontFlag {
+			t.Errorf("MatchTags(%v) wont %v, but got %v", tc.selectors, tc.wontFlag, flag)
+		}
+	}
+}
+
+func TestValidateTagSelectors(t *testing.T) {
+	var testcases = []struct {
+		selectors []string
+		errorFlag bool
+	}{
+		{[]string{"lang=go", "!archived", "team=pay*"}, false},
+		{[]string{"=go"}, true},
+		{[]string{"lang=[go"}, true},
+	}
+	for _, tc := range testcases {
+		if err := ValidateTagSelectors(tc.selectors); (err != nil) != tc.errorFlag {
+			t.Errorf("ValidateTagSelectors(%v) wont error %v, but got %v", tc.selectors, tc.errorFlag, err)
+		}
+	}
+}
+
+func TestTagAndUntagRepository(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		if err := db.TagRepository("unknown", []*Tag{{"lang", "go"}}); err == nil {
+			t.Errorf("unknown repository was tagged")
+		}
+		db.TagRepository("repo1", []*Tag{{"lang", "go"}, {"archived", ""}})
+		db.TagRepository("repo1", []*Tag{{"lang", "rust"}})
+		var repo = db.FindRepository("repo1")
+		if len(repo.Tags) != 2 || repo.FindTag("lang").Value != "rust" {
+			t.Errorf("tags did not match, got: %v", repo.TagStrings())
+		}
+		if err := db.UntagRepository("repo1", []string{"archived", "unknown"}); err == nil {
+			t.Errorf("unknown tag was removed")
+		}
+		if !repo.HasTag("archived") {
+			t.Errorf("archived tag was removed on error")
+		}
+		db.UntagRepository("repo1", []string{"archived"})
+		db.StoreAndClose()
+
+		var db2, _ = Open(config)
+		var repo2 = db2.FindRepository("repo1")
+		if len(repo2.Tags) != 1 || repo2.Tags[0].String() != "lang=rust" {
+			t.Errorf("stored tags did not match, got: %v", repo2.TagStrings())
+		}
+	})
+	defer os.Remove(dbFile)
+}
@@ -24,6 +24,17 @@ func IsInputYes(prompt string) bool {
 	return text == "yes" || text == "y"
 }
 
+func expandHome(path string) string {
+	var home, err = os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		return home + path[1:]
+	}
+	return strings.Replace(path, "${HOME}", home, 1)
+}
+
 /*
 CreateParentDir create the parent directories of the given path.
 */
@@ -0,0 +1,112 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+WalkOption represents the options for searching the git repositories in the directories.
+MaxDepth is the depth of the directories from the given directory to walk, and 0 means unlimited.
+Ignores is the glob patterns of the directories to skip, matched with both of the name and the relative path from the given directory.
+FollowSymlinks walks the symbolic links to the directories, and each directory is walked at most once.
+*/
+type WalkOption struct {
+	MaxDepth       int
+	Ignores        []string
+	FollowSymlinks bool
+}
+
+/*
+NewWalkOption creates the default WalkOption, which walks all of the directories without following the symbolic links.
+*/
+func NewWalkOption() *WalkOption {
+	return &WalkOption{MaxDepth: 0, Ignores: []string{}, FollowSymlinks: false}
+}
+
+/*
+FindGitRepositories walks the given directories with the default option and returns the paths of the git repositories in them.
+*/
+func FindGitRepositories(roots ...string) ([]string, error) {
+	return NewWalkOption().FindGitRepositories(roots...)
+}
+
+/*
+FindGitRepositories walks the given directories and returns the paths of the git repositories in them.
+The directories in the found git repositories and the hidden directories are not walked.
+The unreadable directories are skipped, except the given directories.
+*/
+func (opt *WalkOption) FindGitRepositories(roots ...string) ([]string, error) {
+	var results = []string{}
+	var visited = map[string]bool{}
+	for _, root := range roots {
+		var info, err = os.Stat(root)
+		if err != nil {
+			return results, err
+		}
+		if !info.IsDir() {
+			return results, &os.PathError{Op: "walk", Path: root, Err: os.ErrInvalid}
+		}
+		results = opt.walk(root, root, 0, visited, results)
+	}
+	return results, nil
+}
+
+func (opt *WalkOption) walk(root, path string, depth int, visited map[string]bool, results []string) []string {
+	var realPath, err = filepath.EvalSymlinks(path)
+	if err != nil || visited[realPath] {
+		return results
+	}
+	visited[realPath] = true
+	if isGitRepositoryDir(path) {
+		return append(results, path)
+	}
+	if opt.MaxDepth > 0 && depth >= opt.MaxDepth {
+		return results
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return results
+	}
+	for _, entry := range entries {
+		var child = filepath.Join(path, entry.Name())
+		if opt.isTarget(root, child, entry) {
+			results = opt.walk(root, child, depth+1, visited, results)
+		}
+	}
+	return results
+}
+
+func (opt *WalkOption) isTarget(root, path string, info os.FileInfo) bool {
+	if strings.HasPrefix(info.Name(), ".") || opt.isIgnored(root, path) {
+		return false
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return info.IsDir()
+	}
+	if !opt.FollowSymlinks {
+		return false
+	}
+	var target, err = os.Stat(path)
+	return err == nil && target.IsDir()
+}
+
+func (opt *WalkOption) isIgnored(root, path string) bool {
+	var relPath, _ = filepath.Rel(root, path)
+	for _, pattern := range opt.Ignores {
+		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isGitRepositoryDir(path string) bool {
+	var _, err = os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindGitRepositories(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-walk")
+	defer os.RemoveAll(dir)
+	initGitRepository(t, filepath.Join(dir, "a", "repo1"), "https://github.com/example/repo1.git")
+	initGitRepository(t, filepath.Join(dir, "b", "c", "repo2"), "https://github.com/example/repo2.git")
+	initGitRepository(t, filepath.Join(dir, ".hidden", "repo3"), "https://github.com/example/repo3.git")
+	os.MkdirAll(filepath.Join(dir, "a", "repo1", "nested", ".git"), 0755)
+
+	var paths, err = FindGitRepositories(dir)
+	if err != nil {
+		t.Errorf("walk failed: %s", err.Error())
+	}
+	var wont = []string{filepath.Join(dir, "a", "repo1"), filepath.Join(dir, "b", "c", "repo2")}
+	if len(paths) != len(wont) || paths[0] != wont[0] || paths[1] != wont[1] {
+		t.Errorf("found repositories did not match, wont: %v, got: %v", wont, paths)
+	}
+	if _, err := FindGitRepositories(filepath.Join(dir, "not-exist")); err == nil {
+		t.Errorf("walking the not existing directory should be error")
+	}
+}
+
+func TestFindGitRepositoriesWithOption(t *testing.T) {
+	var dir, _ = ioutil.TempDir("", "rrh-walk")
+	defer os.RemoveAll(dir)
+	initGitRepository(t, filepath.Join(dir, "a", "repo1"), "https://github.com/example/repo1.git")
+	initGitRepository(t, filepath.Join(dir, "b", "c", "repo2"), "https://github.com/example/repo2.git")
+	initGitRepository(t, filepath.Join(dir, "vendor", "repo3"), "https://github.com/example/repo3.git")
+	var other, _ = ioutil.TempDir("", "rrh-walk-link")
+	defer os.RemoveAll(other)
+	initGitRepository(t, filepath.Join(other, "repo4"), "https://github.com/example/repo4.git")
+	os.Symlink(other, filepath.Join(dir, "link"))
+	os.Symlink(dir, filepath.Join(dir, "a", "loop"))
+
+	var testcases = []struct {
+		option *WalkOption
+		wont   []string
+	}{
+		{NewWalkOption(), []string{"a/repo1", "b/c/repo2", "vendor/repo3"}},
+		{&WalkOption{MaxDepth: 2}, []string{"a/repo1", "vendor/repo3"}},
+		{&WalkOption{Ignores: []string{"vendor", "b/*"}}, []string{"a/repo1"}},
+		{&WalkOption{FollowSymlinks: true}, []string{"a/repo1", "b/c/repo2", "link/repo4", "vendor/repo3"}},
+	}
+	for _, tc := range testcases {
+		var paths, err = tc.option.FindGitRepositories(dir)
+		if err != nil {
+			t.Errorf("%v: walk failed: %s", tc.option, err.Error())
+		}
+		var got = []string{}
+		for _, path := range paths {
+			var rel, _ = filepath.Rel(dir, path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		if strings.Join(got, ",") != strings.Join(tc.wont, ",") {
+			t.Errorf("%v: found repositories did not match, wont: %v, got: %v", tc.option, tc.wont, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package rrh
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+/*
+WorkspaceRoot represents the named root directory of the repositories, such as `src=~/src`.
+The paths of the repositories under the root are stored as the relative paths from the root,
+therefore, the database is portable among the machines having the different directory layouts.
+*/
+type WorkspaceRoot struct {
+	Name string
+	Path string
+}
+
+func (root *WorkspaceRoot) String() string {
+	return root.Name + "=" + root.Path
+}
+
+/*
+ParseWorkspaceRoots parses the value of RRH_WORKSPACE_ROOTS (e.g., `src=~/src,work=${HOME}/work`).
+The paths are expanded to the absolute paths.
+*/
+func ParseWorkspaceRoots(value string) ([]*WorkspaceRoot, error) {
+	var roots = []*WorkspaceRoot{}
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		var root, err = ParseWorkspaceRoot(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roots {
+			if r.Name == root.Name {
+				return nil, fmt.Errorf("%s: duplicated workspace root name", root.Name)
+			}
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+/*
+ParseWorkspaceRoot parses the given string in the form of `NAME=PATH` to WorkspaceRoot.
+*/
+func ParseWorkspaceRoot(str string) (*WorkspaceRoot, error) {
+	var items = strings.SplitN(str, "=", 2)
+	if len(items) != 2 {
+		return nil, fmt.Errorf("%s: invalid workspace root, the root must be NAME=PATH form", str)
+	}
+	var name, path = strings.TrimSpace(items[0]), strings.TrimSpace(items[1])
+	if name == "" || strings.ContainsAny(name, " /") {
+		return nil, fmt.Errorf("%s: invalid workspace root name", str)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s: empty workspace root path", str)
+	}
+	var absPath, err = filepath.Abs(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	return &WorkspaceRoot{Name: name, Path: absPath}, nil
+}
+
+/*
+WorkspaceRoots returns the workspace roots defined in RRH_WORKSPACE_ROOTS.
+The invalid definitions are ignored.
+*/
+func (config *Config) WorkspaceRoots() []*WorkspaceRoot {
+	var roots, err = ParseWorkspaceRoots(config.GetValue(WorkspaceRoots))
+	if err != nil {
+		return []*WorkspaceRoot{}
+	}
+	return roots
+}
+
+/*
+FindWorkspaceRoot returns the root containing the given path and the relative path from the root.
+If some roots contain the path, the deepest root is returned.
+*/
+func FindWorkspaceRoot(roots []*WorkspaceRoot, path string) (*WorkspaceRoot, string) {
+	var found *WorkspaceRoot
+	var relPath = ""
+	for _, root := range roots {
+		var rel, err = filepath.Rel(root.Path, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if found == nil || len(root.Path) > len(found.Path) {
+			found, relPath = root, rel
+		}
+	}
+	return found, relPath
+}
+
+func findRootByName(roots []*WorkspaceRoot, name string) *WorkspaceRoot {
+	for _, root := range roots {
+		if root.Name == name {
+			return root
+		}
+	}
+	return nil
+}
+
+/*
+IsResolved returns false if the path of the repository is relative from the workspace root not defined in this machine.
+*/
+func (r *Repository) IsResolved() bool {
+	return r.Root == "" || filepath.IsAbs(r.Path)
+}
+
+/*
+ResolveRepositoryPath converts the relative path of the given repository from its workspace root to the absolute path.
+This function returns an error if the root of the repository is not found in the given roots.
+*/
+func ResolveRepositoryPath(repo *Repository, roots []*WorkspaceRoot) error {
+	if repo.IsResolved() {
+		return nil
+	}
+	var root = findRootByName(roots, repo.Root)
+	if root == nil {
+		return fmt.Errorf("%s: workspace root %s not found", repo.ID, repo.Root)
+	}
+	repo.Path = filepath.Join(root.Path, filepath.FromSlash(repo.Path))
+	return nil
+}
+
+func resolveRepositoryPaths(db *Database, roots []*WorkspaceRoot) {
+	for _, repo := range db.Repositories {
+		// the repositories of the unknown roots remain relative paths, and are stored as is.
+		ResolveRepositoryPath(repo, roots)
+	}
+}
+
+func portablePath(root string, path string, roots []*WorkspaceRoot) (string, string) {
+	if root != "" && !filepath.IsAbs(path) {
+		return root, path
+	}
+	var found, relPath = FindWorkspaceRoot(roots, path)
+	if found == nil {
+		return "", path
+	}
+	return found.Name, filepath.ToSlash(relPath)
+}
+
+func portableRepository(repo *Repository, roots []*WorkspaceRoot) *Repository {
+	var portable = *repo
+	if repo.current != nil {
+		portable.HostPaths = portableHostPaths(repo, roots)
+		portable.Root, portable.Path = portablePath(repo.defaultRoot, repo.defaultPath, roots)
+		return &portable
+	}
+	portable.Root, portable.Path = portablePath(repo.Root, repo.Path, roots)
+	return &portable
+}
+
+/*
+MarshalJSON stores the paths of the repositories as the relative paths from the workspace roots.
+*/
+func (db *Database) MarshalJSON() ([]byte, error) {
+	type plainDatabase Database
+	var portable = plainDatabase(*db)
+	var roots = []*WorkspaceRoot{}
+	if db.Config != nil {
+		roots = db.Config.WorkspaceRoots()
+	}
+	portable.Repositories = []*Repository{}
+	for _, repo := range db.Repositories {
+		portable.Repositories = append(portable.Repositories, portableRepository(repo, roots))
+	}
+	return json.Marshal(&portable)
+}
@@ -0,0 +1,107 @@
+package rrh
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseWorkspaceRoots(t *testing.T) {
+	var home, _ = os.UserHomeDir()
+	var testcases = []struct {
+		value     string
+		errorFlag bool
+		wontRoots []string
+	}{
+		{"", false, []string{}},
+		{"src=~/src", false, []string{"src=" + filepath.Join(home, "src")}},
+		{"src=${HOME}/src, work=/opt/work", false, []string{"src=" + filepath.Join(home, "src"), "work=/opt/work"}},
+		{"src", true, []string{}},
+		{"=/opt/src", true, []string{}},
+		{"src=", true, []string{}},
+		{"src=/opt/src,src=/opt/src2", true, []string{}},
+	}
+	for _, tc := range testcases {
+		var roots, err = ParseWorkspaceRoots(tc.value)
+		if (err != nil) != tc.errorFlag {
+			t.Errorf("ParseWorkspaceRoots(%s) wont error %v, but got %v", tc.value, tc.errorFlag, err)
+		}
+		if err != nil {
+			continue
+		}
+		var results = []string{}
+		for _, root := range roots {
+			results = append(results, root.String())
+		}
+		if strings.Join(results, ",") != strings.Join(tc.wontRoots, ",") {
+			t.Errorf("ParseWorkspaceRoots(%s) did not match, wont: %v, got: %v", tc.value, tc.wontRoots, results)
+		}
+	}
+}
+
+func TestFindWorkspaceRoot(t *testing.T) {
+	var roots = []*WorkspaceRoot{{"src", "/opt/src"}, {"go", "/opt/src/go"}, {"work", "/opt/work"}}
+	var testcases = []struct {
+		path     string
+		wontRoot string
+		wontRel  string
+	}{
+		{"/opt/src/rrh", "src", "rrh"},
+		{"/opt/src/go/rrh", "go", "rrh"},
+		{"/opt/work", "work", "."},
+		{"/opt/workspace/rrh", "", ""},
+		{"/home/user/rrh", "", ""},
+	}
+	for _, tc := range testcases {
+		var root, rel = FindWorkspaceRoot(roots, tc.path)
+		var name = ""
+		if root != nil {
+			name = root.Name
+		}
+		if name != tc.wontRoot || rel != tc.wontRel {
+			t.Errorf("FindWorkspaceRoot(%s) did not match, wont: %s, %s, got: %s, %s", tc.path, tc.wontRoot, tc.wontRel, name, rel)
+		}
+	}
+}
+
+func TestStoreRelativeToWorkspaceRoot(t *testing.T) {
+	var dbFile = Rollback("testdata/test_db.json", "testdata/config.json", func(config *Config, db *Database) {
+		if err := config.Update(WorkspaceRoots, "src"); err == nil {
+			t.Errorf("invalid workspace roots should not be set")
+		}
+		config.Update(WorkspaceRoots, "src=/opt/src")
+		db.CreateRepository("rrh", "/opt/src/tamada/rrh", "", []*Remote{})
+		db.CreateRepository("other", "/opt/other", "", []*Remote{})
+		db.StoreAndClose()
+
+		var bytes, _ = ioutil.ReadFile(config.GetValue(DatabasePath))
+		var content = string(bytes)
+		if !strings.Contains(content, `"repository_id":"rrh","root":"src","repository_path":"tamada/rrh"`) {
+			t.Errorf("repository path should be stored relatively, got: %s", content)
+		}
+		if !strings.Contains(content, `"repository_id":"other","repository_path":"/opt/other"`) {
+			t.Errorf("repository path out of roots should be stored absolutely, got: %s", content)
+		}
+
+		config.Update(WorkspaceRoots, "src=/home/user/work")
+		var db2, _ = Open(config)
+		if path := db2.FindRepository("rrh").Path; path != "/home/user/work/tamada/rrh" {
+			t.Errorf("repository path did not resolved, wont: /home/user/work/tamada/rrh, got: %s", path)
+		}
+
+		config.Unset(WorkspaceRoots)
+		var db3, _ = Open(config)
+		var repo = db3.FindRepository("rrh")
+		if repo.IsResolved() || repo.Path != "tamada/rrh" {
+			t.Errorf("repository of unknown root should not be resolved, got: %s", repo.Path)
+		}
+		db3.StoreAndClose()
+		bytes, _ = ioutil.ReadFile(config.GetValue(DatabasePath))
+		if !strings.Contains(string(bytes), `"root":"src","repository_path":"tamada/rrh"`) {
+			t.Errorf("repository of unknown root should be stored as is, got: %s", string(bytes))
+		}
+	})
+	defer os.Remove(dbFile)
+}